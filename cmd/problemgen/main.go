@@ -0,0 +1,206 @@
+// Command problemgen generates ProblemResponse for the strict server generated by oapi-codegen.
+// ProblemResponse builds the operation's own <Operation><Status>ApplicationProblemPlusJSONResponse
+// object for an error status, so error responses go through the generated response objects
+// instead of being written around them.
+//
+// Usage: go run ./cmd/problemgen -in internal/generated/api/api.gen.go -out internal/generated/api/problems.gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const (
+	problemType = "Problem"
+	suffix      = "ApplicationProblemPlusJSONResponse"
+)
+
+var responseName = regexp.MustCompile(`^([A-Z][A-Za-z0-9]*?)([1-5][0-9]{2})` + suffix + `$`)
+
+type response struct {
+	status int
+	expr   string
+}
+
+func main() {
+	in := flag.String("in", "internal/generated/api/api.gen.go", "file generated by oapi-codegen")
+	out := flag.String("out", "internal/generated/api/problems.gen.go", "output file")
+	flag.Parse()
+
+	if err := run(*in, *out); err != nil {
+		log.Fatalf("problemgen: %s", err)
+	}
+}
+
+func run(in, out string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), in, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", in, err)
+	}
+
+	types := map[string]ast.Expr{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			types[ts.Name.Name] = ts.Type
+		}
+	}
+
+	operations := map[string][]response{}
+	for name := range types {
+		m := responseName.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+
+		expr, err := constructor(types, name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		status, _ := strconv.Atoi(m[2])
+		operations[m[1]] = append(operations[m[1]], response{status: status, expr: expr})
+	}
+
+	src, err := render(file.Name.Name, operations)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
+
+// constructor returns the expression building the named type from the variables problem and retryAfter
+func constructor(types map[string]ast.Expr, name string) (string, error) {
+	if name == problemType {
+		return "problem", nil
+	}
+
+	typ, ok := types[name]
+	if !ok {
+		return "", fmt.Errorf("type %s is not declared", name)
+	}
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+		// a named Problem, e.g. type UnauthorizedApplicationProblemPlusJSONResponse Problem
+		if t.Name != problemType {
+			return "", fmt.Errorf("unsupported underlying type %s", t.Name)
+		}
+
+		return fmt.Sprintf("%s(problem)", name), nil
+	case *ast.StructType:
+		return structConstructor(types, name, t)
+	}
+
+	return "", fmt.Errorf("unsupported type %T", typ)
+}
+
+func structConstructor(types map[string]ast.Expr, name string, t *ast.StructType) (string, error) {
+	fields := t.Fields.List
+
+	// a response of the operation embedding a shared response from components/responses
+	if len(fields) == 1 && len(fields[0].Names) == 0 {
+		embedded, ok := fields[0].Type.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported embedded field")
+		}
+
+		inner, err := constructor(types, embedded.Name)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s{%s}", name, inner), nil
+	}
+
+	// a response with headers: Body and Headers fields
+	var body, headers string
+	for _, f := range fields {
+		ident, ok := f.Type.(*ast.Ident)
+		if !ok || len(f.Names) != 1 {
+			return "", fmt.Errorf("unsupported field")
+		}
+
+		switch f.Names[0].Name {
+		case "Body":
+			body = ident.Name
+		case "Headers":
+			headers = ident.Name
+		default:
+			return "", fmt.Errorf("unsupported field %s", f.Names[0].Name)
+		}
+	}
+
+	if body != problemType {
+		return "", fmt.Errorf("body is %q, want %s", body, problemType)
+	}
+	if headers == "" {
+		return fmt.Sprintf("%s{Body: problem}", name), nil
+	}
+
+	hdr, ok := types[headers].(*ast.StructType)
+	if !ok {
+		return "", fmt.Errorf("headers type %s is not a struct", headers)
+	}
+
+	for _, f := range hdr.Fields.List {
+		if len(f.Names) != 1 || f.Names[0].Name != "RetryAfter" {
+			return "", fmt.Errorf("only the Retry-After header is supported in %s", headers)
+		}
+	}
+
+	return fmt.Sprintf("%s{Body: problem, Headers: %s{RetryAfter: retryAfter}}", name, headers), nil
+}
+
+func render(pkg string, operations map[string][]response) ([]byte, error) {
+	names := make([]string, 0, len(operations))
+	for name := range operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by problemgen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	b.WriteString("// ProblemResponse returns the response object of the operation for an error status,\n")
+	b.WriteString("// false if the operation doesn't declare an application/problem+json response with this status.\n")
+	b.WriteString("// retryAfter is only used by responses declaring the Retry-After header.\n")
+	b.WriteString("func ProblemResponse(operationID string, status int, problem Problem, retryAfter int) (interface{}, bool) {\n")
+	b.WriteString("switch operationID {\n")
+
+	for _, name := range names {
+		responses := operations[name]
+		sort.Slice(responses, func(i, j int) bool { return responses[i].status < responses[j].status })
+
+		fmt.Fprintf(&b, "case %q:\nswitch status {\n", name)
+		for _, r := range responses {
+			fmt.Fprintf(&b, "case %d:\nreturn %s, true\n", r.status, r.expr)
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString("}\n\nreturn nil, false\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format: %w", err)
+	}
+
+	return src, nil
+}
@@ -2,18 +2,32 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
-	"strconv"
+	"syscall"
 	"time"
 
-	"github.com/siyoga/rollstory/internal/api/ping"
+	rpc "github.com/siyoga/rollstory/internal/api"
+	appAPIKey "github.com/siyoga/rollstory/internal/app/apikey"
+	"github.com/siyoga/rollstory/internal/config"
 	"github.com/siyoga/rollstory/internal/generated/api"
+	psql "github.com/siyoga/rollstory/internal/inf/postgres/public"
 	bootstrap "github.com/siyoga/rollstory/internal/init"
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/health"
+	"github.com/siyoga/rollstory/pkg/http/admin"
 	"github.com/siyoga/rollstory/pkg/http/listener"
+	"github.com/siyoga/rollstory/pkg/http/openapi"
 	"github.com/siyoga/rollstory/pkg/http/router"
+	"github.com/siyoga/rollstory/pkg/http/router/middleware"
 	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/policy"
+	"github.com/siyoga/rollstory/pkg/ratelimit"
+	"github.com/siyoga/rollstory/pkg/session"
 )
 
 const (
@@ -21,12 +35,25 @@ const (
 	fail    = 1
 )
 
+// probePatterns are polled every few seconds and are kept out of the access log
+var probePatterns = []string{"GET /healthz", "GET /readyz"}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == migrateCommand {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
 	os.Exit(run())
 }
 
 func run() (exitCode int) {
-	log, err := logger.New()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Println(err.Error())
+		return fail
+	}
+
+	log, err := logger.New(logger.WithConfig(cfg.Logger))
 	if err != nil {
 		fmt.Println("while init logger: ", err.Error())
 		return fail
@@ -36,7 +63,7 @@ func run() (exitCode int) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
-	container := bootstrap.NewContainer()
+	container := bootstrap.NewContainer(ctx, cfg, log)
 	rt := router.NewRouter(
 		log,
 		router.DefaultBadRequestErrHandler,
@@ -44,54 +71,239 @@ func run() (exitCode int) {
 		router.DefaultPanicHandler(log),
 	)
 
-	// register handlers
-	// Each RPC handler implements api.StrictServerInterface partially
-	// For now, we have only ping handler, in future we'll combine multiple handlers
-	if err := container.Invoke(func(pingHandler *ping.Handler) {
-		// Use ping handler directly as it implements StrictServerInterface
-		strictHandler := api.NewStrictHandler(pingHandler, nil)
-		api.HandlerWithOptions(strictHandler, api.StdHTTPServerOptions{
-			BaseRouter: rt,
-		})
+	spec, err := api.GetSwagger()
+	if err != nil {
+		log.Error(ctx, "failed to load OpenAPI spec", err)
+		return fail
+	}
+
+	var (
+		tokens   *auth.TokenIssuer
+		apiKeys  *appAPIKey.Handler
+		sessions *session.Manager
+		access   *policy.Engine
+	)
+	if err := container.Invoke(func(t *auth.TokenIssuer, k *appAPIKey.Handler, s *session.Manager, p *policy.Engine) {
+		tokens, apiKeys, sessions, access = t, k, s, p
 	}); err != nil {
-		log.Error(ctx, "failed to invoke handlers", err)
+		log.Error(ctx, "failed to init authentication", err)
 		return fail
 	}
 
-	listenerReadTimeout, err := strconv.Atoi(os.Getenv("LISTENER_READ_TIMEOUT"))
+	permissions, err := openapi.Permissions(spec)
 	if err != nil {
-		log.Warning(ctx, "can not parse listenerReadTimeout, using default value = 5")
-		listenerReadTimeout = 5 // Default value
+		log.Error(ctx, "failed to read operation permissions", err)
+		return fail
 	}
 
-	listenerWriteTimeout, err := strconv.Atoi(os.Getenv("LISTENER_WRITE_TIMEOUT"))
+	publicRoutes, err := openapi.PublicRoutes(spec)
 	if err != nil {
-		log.Warning(ctx, "can not parse listenerWriteTimeout, using default value = 5")
-		listenerWriteTimeout = 5 // Default value
+		log.Error(ctx, "failed to read public operations", err)
+		return fail
+	}
+	// the spec and its docs page are served outside the generated handlers
+	publicRoutes["GET /openapi.json"] = true
+	publicRoutes["GET /docs"] = true
+
+	// the first registered middleware runs first
+	rt.Use(middleware.RequestID(log))
+	rt.Use(middleware.Except(middleware.AccessLog(log), probePatterns...))
+	if cfg.HTTP.AdaptiveConcurrency {
+		// a single limiter for every route, probes must keep answering under load
+		rt.Use(middleware.Except(middleware.AdaptiveConcurrency(cfg.HTTP.ConcurrencyOptions()), probePatterns...))
+	}
+
+	var limiterStore ratelimit.Store
+	if err := container.Invoke(func(s ratelimit.Store) { limiterStore = s }); err != nil {
+		log.Error(ctx, "failed to init rate limiter", err)
+		return fail
 	}
 
-	listenerIdleTimeout, err := strconv.Atoi(os.Getenv("LISTENER_IDLE_TIMEOUT"))
+	// the IP limiter runs before authentication, so guessing credentials and signatures is throttled too
+	rt.Use(middleware.Except(middleware.RateLimit(middleware.RateLimitOptions{
+		Store:   limiterStore,
+		Key:     cfg.HTTP.IPKeyFunc(),
+		Default: cfg.HTTP.RateLimit,
+		Routes:  cfg.HTTP.RateLimitRoutes,
+		Logger:  log,
+	}), probePatterns...))
+	rt.Use(middleware.Authenticate(middleware.AuthOptions{
+		Verifier:           tokens,
+		APIKeys:            apiKeys,
+		MaxSignedBodyBytes: cfg.HTTP.MaxBodyBytes,
+		Sessions:           sessions,
+		Required:           openapi.SecuredRoutes(spec),
+		Logger:             log,
+	}))
+	// cross-site requests riding on the session cookie, and cross-site logins that would plant
+	// the attacker's session, are rejected before any work is done
+	rt.Use(middleware.CSRF(middleware.CSRFOptions{
+		OriginChecked:  map[string]bool{"POST /auth/session": true},
+		AllowedOrigins: cfg.HTTP.CORSAllowedOrigins,
+		Logger:         log,
+	}))
+
+	if key := cfg.HTTP.RateLimitKeyFunc(); key != nil {
+		// per-user or per-route quotas need the identity set by Authenticate
+		rt.Use(middleware.Except(middleware.RateLimit(middleware.RateLimitOptions{
+			Store:   limiterStore,
+			Key:     key,
+			Default: cfg.HTTP.RateLimit,
+			Routes:  cfg.HTTP.RateLimitRoutes,
+			Logger:  log,
+		}), probePatterns...))
+	}
+	// every route either declares x-permissions or is marked x-public, anything else is denied
+	rt.Use(middleware.Authorize(middleware.AuthorizeOptions{
+		Policy:      access,
+		Permissions: permissions,
+		Public:      publicRoutes,
+		Logger:      log,
+	}))
+	rt.Use(middleware.Timeout(cfg.HTTP.RequestTimeout, nil))
+	rt.Use(middleware.MaxBody(cfg.HTTP.MaxBodyBytes, nil))
+	rt.Use(middleware.Compress(middleware.CompressOptions{MinSize: cfg.HTTP.CompressMinSize}))
+
+	// validation runs inside compression so responses are checked uncompressed
+	validator, err := middleware.OpenAPIValidator(spec, middleware.OpenAPIOptions{
+		ValidateResponses: cfg.HTTP.ValidateResponses && cfg.Environment != config.EnvironmentProduction,
+		Logger:            log,
+	})
 	if err != nil {
-		log.Warning(ctx, "can not parse listenerIdleTimeout, using default value = 5")
-		listenerIdleTimeout = 5 // Default value
+		log.Error(ctx, "failed to init OpenAPI validator", err)
+		return fail
 	}
+	rt.Use(validator)
 
-	port, err := strconv.Atoi(os.Getenv("PORT"))
+	specHandler, err := openapi.SpecHandler(spec)
 	if err != nil {
-		log.Warning(ctx, "can not parse port, using default value = 8080")
-		port = 8080
+		log.Error(ctx, "failed to serve OpenAPI spec", err)
+		return fail
+	}
+	rt.Method(http.MethodGet, "/openapi.json", specHandler)
+	if cfg.Docs.RedocIntegrity == "" {
+		log.Warning(ctx, "DOCS_REDOC_INTEGRITY is not set, the docs page loads Redoc without an integrity check")
+	}
+	rt.Method(http.MethodGet, "/docs", openapi.DocsHandler(
+		spec.Info.Title,
+		"/openapi.json",
+		openapi.WithRedoc(cfg.Docs.RedocURL, cfg.Docs.RedocIntegrity),
+	))
+
+	if cfg.MigrateOnStart {
+		if err := container.Invoke(func(conn *psql.Connection) error {
+			return applyMigrations(ctx, conn, log)
+		}); err != nil {
+			log.Error(ctx, "failed to apply migrations", err)
+			return fail
+		}
 	}
 
-	ln := listener.New(
+	// register handlers
+	// Server combines every RPC handler into a single StrictServerInterface
+	if err := container.Invoke(func(server *rpc.Server, errorHandler *rpc.ErrorHandler) {
+		// every error leaves the API as application/problem+json
+		strictHandler := api.NewStrictHandlerWithOptions(server, []api.StrictMiddlewareFunc{
+			errorHandler.Middleware,
+		}, api.StrictHTTPServerOptions{
+			RequestErrorHandlerFunc:  rt.BadRequest,
+			ResponseErrorHandlerFunc: rt.InternalError,
+		})
+		api.HandlerWithOptions(strictHandler, api.StdHTTPServerOptions{
+			BaseRouter: rt,
+			ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+				rt.BadRequest(w, r, rpc.RequestError(err))
+			},
+		})
+	}); err != nil {
+		log.Error(ctx, "failed to invoke handlers", err)
+		return fail
+	}
+
+	for _, route := range rt.Routes() {
+		log.Debug(ctx, fmt.Sprintf("route %s (%d middlewares)", route.Pattern, route.Middlewares))
+	}
+
+	var (
+		registry *health.Registry
+		prober   *postgres.Prober
+		conn     *psql.Connection
+	)
+	if err := container.Invoke(func(r *health.Registry, p *postgres.Prober, c *psql.Connection) {
+		registry, prober, conn = r, p, c
+	}); err != nil {
+		log.Error(ctx, "failed to init health checks", err)
+		return fail
+	}
+
+	public := listener.New(
 		listener.With(log),
-		listener.WithIdleTimeout(time.Duration(listenerIdleTimeout)*time.Second),
-		listener.WithReadTimeout(time.Duration(listenerReadTimeout)*time.Second),
-		listener.WithWriteTimeout(time.Duration(listenerWriteTimeout)*time.Second),
+		listener.WithConfig(cfg.Listener),
+		// readiness starts failing as soon as shutdown begins
+		listener.WithBeforeShutdown(func() { registry.SetShuttingDown(true) }),
 	)
 
-	log.Info(ctx, fmt.Sprintf("listening on port %d", port))
-	if err := ln.Listen(ctx, port, rt); err != nil {
-		log.Error(ctx, "server error", err)
+	// CORS wraps the whole router to answer preflight requests that match no route
+	cors, err := middleware.CORS(cfg.HTTP.CORSOptions())
+	if err != nil {
+		log.Error(ctx, "invalid CORS configuration", err)
+		return fail
+	}
+
+	group := listener.NewGroup(log).Add("public", public, cfg.Listener.Port, cors(rt))
+
+	if cfg.Admin.Port > 0 {
+		adminHandler := admin.New(
+			admin.WithHealth(registry),
+			admin.WithLogLevel(log),
+			admin.WithPprof(cfg.Admin.Pprof),
+			admin.WithMetric("postgres", func() any { return prober.Stats() }),
+		)
+
+		// admin keeps answering probes during the pre-stop delay of the public listener
+		adminListener := listener.New(
+			listener.With(log),
+			listener.WithPreStopDelay(cfg.Listener.PreStopDelay),
+		)
+
+		group.Add("admin", adminListener, cfg.Admin.Port, adminHandler)
+	}
+
+	// SIGHUP re-executes the binary, handing listening sockets over to the new process
+	if cfg.Listener.GracefulUpgrade {
+		group.UpgradeOn(syscall.SIGHUP, 0)
+	}
+
+	// hooks run in order once every listener has drained its in-flight requests
+	group.
+		OnShutdown("stop rate limit cleanup", 0, func(context.Context) error {
+			if store, ok := limiterStore.(*ratelimit.PostgresStore); ok {
+				store.Stop()
+			}
+
+			return nil
+		}).
+		OnShutdown("stop session cleanup", 0, func(context.Context) error {
+			sessions.Stop()
+			return nil
+		}).
+		OnShutdown("stop postgres prober", 0, func(context.Context) error {
+			prober.Stop()
+			return nil
+		}).
+		OnShutdown("close postgres pool", 0, func(context.Context) error {
+			return conn.Close()
+		}).
+		OnShutdown("flush logger", 2*time.Second, func(context.Context) error {
+			if !log.Flush(time.Second) {
+				return errors.New("logger flush timed out")
+			}
+
+			return nil
+		})
+
+	if err := group.Listen(ctx); err != nil {
+		log.Error(ctx, "server stopped with error", err)
 		return fail
 	}
 
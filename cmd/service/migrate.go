@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/siyoga/rollstory/internal/config"
+	"github.com/siyoga/rollstory/internal/inf/postgres/migrations"
+	psql "github.com/siyoga/rollstory/internal/inf/postgres/public"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/db/postgres/migrate"
+	"github.com/siyoga/rollstory/pkg/logger"
+)
+
+const (
+	migrateCommand = "migrate"
+
+	defaultMigrationsDir = "internal/inf/postgres/migrations"
+)
+
+const migrateUsage = `usage: service migrate <command> [flags]
+
+commands:
+  up [-steps N] [-dry-run]     apply pending migrations (all by default)
+  down [-steps N] [-dry-run]   revert applied migrations (one by default)
+  status                       list migrations and their state
+  create [-dir DIR] <name>     create empty up/down migration files`
+
+// runMigrate implements `service migrate up|down|status|create`
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Println(migrateUsage)
+		return fail
+	}
+
+	command, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet(migrateCommand+" "+command, flag.ContinueOnError)
+	steps := fs.Int("steps", 0, "number of migrations to apply or revert")
+	dryRun := fs.Bool("dry-run", false, "print migrations without executing them")
+	dir := fs.String("dir", defaultMigrationsDir, "directory for new migration files")
+
+	if err := fs.Parse(args); err != nil {
+		return fail
+	}
+
+	if command == "create" {
+		if fs.NArg() != 1 {
+			fmt.Println(migrateUsage)
+			return fail
+		}
+
+		up, down, err := migrate.Create(*dir, fs.Arg(0))
+		if err != nil {
+			fmt.Println("while creating migration: ", err.Error())
+			return fail
+		}
+
+		fmt.Printf("created %s\ncreated %s\n", up, down)
+		return success
+	}
+
+	cfg, err := config.Load(nil)
+	if err != nil {
+		fmt.Println(err.Error())
+		return fail
+	}
+
+	log, err := logger.New(logger.WithConfig(cfg.Logger))
+	if err != nil {
+		fmt.Println("while init logger: ", err.Error())
+		return fail
+	}
+	defer log.Flush(time.Second)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	conn, err := psql.New(ctx, cfg.Postgres, postgres.UserRoleFullAccess, log)
+	if err != nil {
+		log.Error(ctx, "failed to connect to postgres", err)
+		return fail
+	}
+	defer conn.Close()
+
+	migrator, err := newMigrator(conn.DB, log, migrate.WithDryRun(*dryRun))
+	if err != nil {
+		log.Error(ctx, "failed to load migrations", err)
+		return fail
+	}
+
+	switch command {
+	case "up":
+		_, err = migrator.Up(ctx, *steps)
+	case "down":
+		if *steps == 0 {
+			*steps = 1
+		}
+		_, err = migrator.Down(ctx, *steps)
+	case "status":
+		err = printMigrationStatus(ctx, migrator)
+	default:
+		fmt.Println(migrateUsage)
+		return fail
+	}
+
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("migrate %s failed", command), err)
+		return fail
+	}
+
+	return success
+}
+
+func newMigrator(db *sqlx.DB, log *logger.Logger, opts ...migrate.Option) (*migrate.Migrator, error) {
+	return migrate.New(db, migrations.FS, append([]migrate.Option{migrate.WithLogger(log)}, opts...)...)
+}
+
+// applyMigrations applies all pending migrations, used when MIGRATE_ON_START is enabled
+func applyMigrations(ctx context.Context, conn *psql.Connection, log *logger.Logger) error {
+	migrator, err := newMigrator(conn.DB, log)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+
+	applied, err := migrator.Up(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	log.Info(ctx, fmt.Sprintf("applied %d migrations", len(applied)))
+
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		status, appliedAt := "pending", "-"
+		if s.Applied {
+			status, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,19 @@
+package apikey
+
+import (
+	"context"
+
+	appAPIKey "github.com/siyoga/rollstory/internal/app/apikey"
+)
+
+// APIKeyHandler defines the interface for API key business logic
+type APIKeyHandler interface {
+	Create(ctx context.Context, userID string, req appAPIKey.CreateRequest) (*appAPIKey.Created, error)
+	List(ctx context.Context, userID string) ([]appAPIKey.APIKey, error)
+	Revoke(ctx context.Context, userID string, id string) error
+}
+
+// ErrorHandler defines the interface for error handling
+type ErrorHandler interface {
+	Handle(ctx context.Context, err error) error
+}
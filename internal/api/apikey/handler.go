@@ -0,0 +1,120 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	appAPIKey "github.com/siyoga/rollstory/internal/app/apikey"
+	"github.com/siyoga/rollstory/internal/generated/api"
+	"github.com/siyoga/rollstory/pkg/apperror"
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/logger"
+)
+
+// Handler is a thin RPC adapter for the /api-keys endpoints
+type Handler struct {
+	apiKeyHandler APIKeyHandler
+	errorHandler  ErrorHandler
+	log           *logger.Logger
+}
+
+// NewHandler creates a new RPC API key handler
+func NewHandler(
+	apiKeyHandler APIKeyHandler,
+	errorHandler ErrorHandler,
+	log *logger.Logger,
+) *Handler {
+	return &Handler{
+		apiKeyHandler: apiKeyHandler,
+		errorHandler:  errorHandler,
+		log:           log,
+	}
+}
+
+// GetApiKeys handles the GET /api-keys endpoint
+func (h *Handler) GetApiKeys(ctx context.Context, _ api.GetApiKeysRequestObject) (api.GetApiKeysResponseObject, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	keys, err := h.apiKeyHandler.List(ctx, userID)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	items := make([]api.APIKey, 0, len(keys))
+	for i := range keys {
+		items = append(items, toAPIKey(&keys[i]))
+	}
+
+	return api.GetApiKeys200JSONResponse{Items: items}, nil
+}
+
+// PostApiKeys handles the POST /api-keys endpoint
+func (h *Handler) PostApiKeys(ctx context.Context, request api.PostApiKeysRequestObject) (api.PostApiKeysResponseObject, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	created, err := h.apiKeyHandler.Create(ctx, userID, appAPIKey.CreateRequest{
+		Name:      request.Body.Name,
+		Scopes:    request.Body.Scopes,
+		ExpiresAt: request.Body.ExpiresAt,
+		Signing:   request.Body.Signing != nil && *request.Body.Signing,
+	})
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	response := api.PostApiKeys201JSONResponse{
+		ApiKey: toAPIKey(created.APIKey),
+		Key:    created.Key,
+	}
+	if created.SigningSecret != "" {
+		response.SigningSecret = &created.SigningSecret
+	}
+
+	return response, nil
+}
+
+// DeleteApiKeysId handles the DELETE /api-keys/{id} endpoint
+func (h *Handler) DeleteApiKeysId(ctx context.Context, request api.DeleteApiKeysIdRequestObject) (api.DeleteApiKeysIdResponseObject, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	if err := h.apiKeyHandler.Revoke(ctx, userID, request.Id.String()); err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.DeleteApiKeysId204Response{}, nil
+}
+
+// currentUser returns the user set by the authentication middleware
+func currentUser(ctx context.Context) (string, error) {
+	userID, ok := pkgAuth.UserIDFromContext(ctx)
+	if !ok {
+		return "", apperror.Unauthorized("unauthorized", "authentication required")
+	}
+
+	return userID, nil
+}
+
+func toAPIKey(key *appAPIKey.APIKey) api.APIKey {
+	id, _ := uuid.Parse(key.ID)
+
+	return api.APIKey{
+		Id:         id,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		Signing:    key.Signing(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	appAuth "github.com/siyoga/rollstory/internal/app/auth"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// AuthHandler defines the interface for auth business logic
+type AuthHandler interface {
+	Register(ctx context.Context, email string, password string) (*appAuth.User, error)
+	Login(ctx context.Context, email string, password string) (*appAuth.Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*appAuth.Tokens, error)
+	Logout(ctx context.Context, userID string, refreshToken string) error
+	Me(ctx context.Context, userID string) (*appAuth.User, error)
+	StartSession(ctx context.Context, email string, password string) (*appAuth.SessionLogin, error)
+	CurrentSession(ctx context.Context) (*appAuth.User, *session.Session, error)
+	EndSession(ctx context.Context) (http.Cookie, error)
+}
+
+// ErrorHandler defines the interface for error handling
+type ErrorHandler interface {
+	Handle(ctx context.Context, err error) error
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	appAuth "github.com/siyoga/rollstory/internal/app/auth"
+	"github.com/siyoga/rollstory/internal/generated/api"
+	"github.com/siyoga/rollstory/pkg/apperror"
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/http/router/response"
+	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// Handler is a thin RPC adapter for the /auth endpoints
+type Handler struct {
+	authHandler  AuthHandler
+	errorHandler ErrorHandler
+	log          *logger.Logger
+}
+
+// NewHandler creates a new RPC auth handler
+func NewHandler(
+	authHandler AuthHandler,
+	errorHandler ErrorHandler,
+	log *logger.Logger,
+) *Handler {
+	return &Handler{
+		authHandler:  authHandler,
+		errorHandler: errorHandler,
+		log:          log,
+	}
+}
+
+// PostAuthRegister handles the POST /auth/register endpoint
+func (h *Handler) PostAuthRegister(ctx context.Context, request api.PostAuthRegisterRequestObject) (api.PostAuthRegisterResponseObject, error) {
+	user, err := h.authHandler.Register(ctx, string(request.Body.Email), request.Body.Password)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.PostAuthRegister201JSONResponse(toUser(user)), nil
+}
+
+// PostAuthLogin handles the POST /auth/login endpoint
+func (h *Handler) PostAuthLogin(ctx context.Context, request api.PostAuthLoginRequestObject) (api.PostAuthLoginResponseObject, error) {
+	tokens, err := h.authHandler.Login(ctx, string(request.Body.Email), request.Body.Password)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.PostAuthLogin200JSONResponse(toTokenPair(tokens)), nil
+}
+
+// PostAuthRefresh handles the POST /auth/refresh endpoint
+func (h *Handler) PostAuthRefresh(ctx context.Context, request api.PostAuthRefreshRequestObject) (api.PostAuthRefreshResponseObject, error) {
+	tokens, err := h.authHandler.Refresh(ctx, request.Body.RefreshToken)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.PostAuthRefresh200JSONResponse(toTokenPair(tokens)), nil
+}
+
+// PostAuthLogout handles the POST /auth/logout endpoint
+func (h *Handler) PostAuthLogout(ctx context.Context, request api.PostAuthLogoutRequestObject) (api.PostAuthLogoutResponseObject, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	if err := h.authHandler.Logout(ctx, userID, request.Body.RefreshToken); err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.PostAuthLogout204Response{}, nil
+}
+
+// GetAuthMe handles the GET /auth/me endpoint
+func (h *Handler) GetAuthMe(ctx context.Context, _ api.GetAuthMeRequestObject) (api.GetAuthMeResponseObject, error) {
+	userID, err := currentUser(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	user, err := h.authHandler.Me(ctx, userID)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.GetAuthMe200JSONResponse(toUser(user)), nil
+}
+
+// PostAuthSession handles the POST /auth/session endpoint
+func (h *Handler) PostAuthSession(ctx context.Context, request api.PostAuthSessionRequestObject) (api.PostAuthSessionResponseObject, error) {
+	login, err := h.authHandler.StartSession(ctx, string(request.Body.Email), request.Body.Password)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return sessionStarted{cookie: login.Cookie, body: toSession(login.User, login.Session)}, nil
+}
+
+// GetAuthSession handles the GET /auth/session endpoint
+func (h *Handler) GetAuthSession(ctx context.Context, _ api.GetAuthSessionRequestObject) (api.GetAuthSessionResponseObject, error) {
+	user, s, err := h.authHandler.CurrentSession(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return api.GetAuthSession200JSONResponse(toSession(user, s)), nil
+}
+
+// DeleteAuthSession handles the DELETE /auth/session endpoint
+func (h *Handler) DeleteAuthSession(ctx context.Context, _ api.DeleteAuthSessionRequestObject) (api.DeleteAuthSessionResponseObject, error) {
+	cookie, err := h.authHandler.EndSession(ctx)
+	if err != nil {
+		return nil, h.errorHandler.Handle(ctx, err)
+	}
+
+	return sessionEnded{cookie: cookie}, nil
+}
+
+// sessionStarted and sessionEnded send the session cookie through response.Builder:
+// the generated responses only take a preformatted Set-Cookie header value
+type sessionStarted struct {
+	cookie http.Cookie
+	body   api.Session
+}
+
+func (r sessionStarted) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	return response.NewResponse().
+		SetNativeCookie(r.cookie).
+		SetStatusCode(http.StatusCreated).
+		SetJsonBody(r.body).
+		Send(w)
+}
+
+type sessionEnded struct {
+	cookie http.Cookie
+}
+
+func (r sessionEnded) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	return response.NewResponse().
+		SetNativeCookie(r.cookie).
+		SetStatusCode(http.StatusNoContent).
+		Send(w)
+}
+
+// currentUser returns the user set by the authentication middleware. Secured operations
+// never reach the handler without one, so a missing user means the middleware isn't registered.
+func currentUser(ctx context.Context) (string, error) {
+	userID, ok := pkgAuth.UserIDFromContext(ctx)
+	if !ok {
+		return "", apperror.Unauthorized("unauthorized", "authentication required")
+	}
+
+	return userID, nil
+}
+
+func toUser(user *appAuth.User) api.User {
+	id, _ := uuid.Parse(user.ID)
+
+	return api.User{
+		Id:        id,
+		Email:     user.Email,
+		Role:      api.Role(user.Role),
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+func toSession(user *appAuth.User, s *session.Session) api.Session {
+	return api.Session{
+		User:      toUser(user),
+		CsrfToken: s.CSRFToken,
+		ExpiresAt: s.ExpiresAt,
+	}
+}
+
+func toTokenPair(tokens *appAuth.Tokens) api.TokenPair {
+	return api.TokenPair{
+		AccessToken:      tokens.Access.Token,
+		TokenType:        api.Bearer,
+		ExpiresIn:        secondsUntil(tokens.Access.ExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: secondsUntil(tokens.RefreshExpiresAt),
+	}
+}
+
+func secondsUntil(t time.Time) int {
+	return int(math.Ceil(time.Until(t).Seconds()))
+}
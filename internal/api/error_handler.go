@@ -2,8 +2,15 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
 
 	"github.com/siyoga/rollstory/internal/generated/api"
+	"github.com/siyoga/rollstory/pkg/apperror"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/http/problem"
 	"github.com/siyoga/rollstory/pkg/logger"
 )
 
@@ -19,25 +26,166 @@ func NewErrorHandler(log *logger.Logger) *ErrorHandler {
 	}
 }
 
-// Handle converts application errors to appropriate HTTP responses
+// Handle maps an application error to the domain error taxonomy and logs it once:
+// server-side failures at error level, client errors at info level.
+// The result is rendered as application/problem+json by Middleware.
 func (h *ErrorHandler) Handle(ctx context.Context, err error) error {
 	if err == nil {
 		return nil
 	}
 
-	// Log the error
-	h.log.Error(ctx, "RPC error", err)
+	var handled *handledError
+	if errors.As(err, &handled) {
+		return handled
+	}
 
-	// For now, return generic 500 error
-	// In future, you can add specific error type handling here
-	return err
+	appErr := MapError(err)
+
+	// LoggerFields of the error add its kind, code and cause details to the entry
+	logCtx := h.log.WithError(ctx, appErr)
+	if appErr.Kind.HTTPStatus() >= http.StatusInternalServerError {
+		h.log.Error(logCtx, "RPC error")
+	} else {
+		h.log.Info(logCtx, "RPC client error")
+	}
+
+	return &handledError{err: appErr}
 }
 
-// ToInternalServerError creates a 500 response
-func (h *ErrorHandler) ToInternalServerError(message string) api.GetPing500JSONResponse {
-	return api.GetPing500JSONResponse{
-		InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse{
-			Error: message,
-		},
+// Middleware converts errors returned by any strict handler into the operation's generated
+// application/problem+json response object with the status of their kind, so operations don't
+// need to build a response object per error status. Each kind's status matches a shared response
+// in components/responses of api/schema.yaml, which operations declare so the response validator
+// and clients expect them. A status the operation doesn't declare is reported as internal error.
+func (h *ErrorHandler) Middleware(next api.StrictHandlerFunc, operationID string) api.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		response, err := next(ctx, w, r, request)
+		if err == nil {
+			return response, nil
+		}
+
+		appErr := apperror.From(h.Handle(ctx, err))
+		retryAfter := int(math.Ceil(appErr.RetryAfter.Seconds()))
+
+		problemResponse, ok := api.ProblemResponse(operationID, appErr.Kind.HTTPStatus(), apiProblem(appErr.Problem().ForRequest(r)), retryAfter)
+		if !ok {
+			h.log.Error(h.log.WithField(ctx, "http_status", appErr.Kind.HTTPStatus()), fmt.Sprintf("operation %s doesn't declare the error response", operationID))
+
+			problemResponse, ok = api.ProblemResponse(operationID, http.StatusInternalServerError, apiProblem(problem.Internal().ForRequest(r)), 0)
+			if !ok {
+				return nil, err
+			}
+		}
+
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		return problemResponse, nil
 	}
 }
+
+// apiProblem converts a problem to the generated Problem model
+func apiProblem(p *problem.Problem) api.Problem {
+	out := api.Problem{
+		Type:       p.Type,
+		Title:      p.Title,
+		Status:     p.Status,
+		Detail:     optional(p.Detail),
+		Code:       optional(p.Code),
+		Instance:   optional(p.Instance),
+		RequestId:  optional(p.RequestID),
+		IncidentId: optional(p.IncidentID),
+	}
+
+	if len(p.Errors) > 0 {
+		fields := make([]api.FieldError, 0, len(p.Errors))
+		for _, fe := range p.Errors {
+			fields = append(fields, api.FieldError{Field: fe.Field, Message: fe.Message})
+		}
+		out.Errors = &fields
+	}
+
+	return out
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// MapError converts known infrastructure errors into domain errors,
+// anything unknown becomes an internal error with a generic public message.
+// postgres.ErrNotFound is not mapped: only the app layer knows whether the missing row
+// is the addressed resource, so it converts ErrNotFound into a typed apperror itself.
+func MapError(err error) *apperror.Error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var constraint *postgres.ConstraintError
+	errors.As(err, &constraint)
+
+	switch {
+	case errors.Is(err, postgres.ErrUniqueViolation):
+		return apperror.Conflict("already_exists", "resource already exists", apperror.WithCause(err))
+	case errors.Is(err, postgres.ErrForeignKeyViolation):
+		return apperror.Conflict("reference_violation", "related resource does not exist or is still in use", apperror.WithCause(err))
+	case errors.Is(err, postgres.ErrNotNullViolation), errors.Is(err, postgres.ErrCheckViolation):
+		opts := []apperror.Option{apperror.WithCause(err)}
+		if constraint != nil && constraint.Column != "" {
+			opts = append(opts, apperror.WithFieldError(constraint.Column, "invalid value"))
+		}
+
+		return apperror.Validation("invalid_value", "request contains invalid values", opts...)
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperror.Unavailable("timeout", "request timed out, try again later", apperror.WithCause(err))
+	}
+
+	return apperror.Internal(err)
+}
+
+// handledError marks an error that was already mapped and logged by Handle
+type handledError struct {
+	err *apperror.Error
+}
+
+func (e *handledError) Error() string {
+	return e.err.Error()
+}
+
+func (e *handledError) Unwrap() error {
+	return e.err
+}
+
+// RequestError converts parameter binding errors of the generated server into validation errors,
+// so the bad request response lists the offending parameter
+func RequestError(err error) error {
+	var (
+		invalidFormat *api.InvalidParamFormatError
+		required      *api.RequiredParamError
+		requiredHdr   *api.RequiredHeaderError
+		unmarshaling  *api.UnmarshalingParamError
+		tooMany       *api.TooManyValuesForParamError
+		cookie        *api.UnescapedCookieParamError
+	)
+
+	switch {
+	case errors.As(err, &invalidFormat):
+		return problem.Validation(invalidFormat.ParamName, invalidFormat.Err.Error())
+	case errors.As(err, &required):
+		return problem.Validation(required.ParamName, "is required")
+	case errors.As(err, &requiredHdr):
+		return problem.Validation(requiredHdr.ParamName, "is required")
+	case errors.As(err, &unmarshaling):
+		return problem.Validation(unmarshaling.ParamName, unmarshaling.Err.Error())
+	case errors.As(err, &tooMany):
+		return problem.Validation(tooMany.ParamName, "expected a single value")
+	case errors.As(err, &cookie):
+		return problem.Validation(cookie.ParamName, "invalid cookie encoding")
+	}
+
+	return err
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/siyoga/rollstory/internal/generated/api"
+	"github.com/siyoga/rollstory/pkg/apperror"
+	"github.com/siyoga/rollstory/pkg/http/openapi"
+	"github.com/siyoga/rollstory/pkg/logger"
+)
+
+// TestSharedResponsesDeclared checks that every operation declares the problem responses
+// Middleware and the router middlewares can write for it, so validation and clients see them
+func TestSharedResponsesDeclared(t *testing.T) {
+	spec, err := api.GetSwagger()
+	if err != nil {
+		t.Fatalf("GetSwagger() error = %v", err)
+	}
+
+	for path, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			health := len(op.Tags) > 0 && op.Tags[0] == "Health"
+
+			// invalid credentials are rejected and handlers may panic on any route
+			kinds := []apperror.Kind{apperror.KindUnauthorized, apperror.KindInternal}
+			if !health {
+				kinds = append(kinds, apperror.KindRateLimited, apperror.KindUnavailable)
+			}
+			if op.Extensions[openapi.PermissionsExtension] != nil || method != http.MethodGet {
+				kinds = append(kinds, apperror.KindForbidden)
+			}
+
+			var statuses []int
+			for _, kind := range kinds {
+				statuses = append(statuses, kind.HTTPStatus())
+			}
+			if op.RequestBody != nil {
+				statuses = append(statuses, apperror.KindValidation.HTTPStatus(), http.StatusRequestEntityTooLarge)
+			}
+
+			for _, status := range statuses {
+				if op.Responses.Value(strconv.Itoa(status)) == nil {
+					t.Errorf("%s %s doesn't declare response %d", method, path, status)
+				}
+			}
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	log, err := logger.New(logger.WithConfig(logger.Config{Level: "ERROR", Output: "stderr", Format: "json"}))
+	if err != nil {
+		t.Fatalf("logger.New() error = %v", err)
+	}
+
+	h := NewErrorHandler(log)
+
+	tests := []struct {
+		name           string
+		err            error
+		wantResponse   api.GetApiKeysResponseObject
+		wantStatus     int
+		wantCode       string
+		wantRetryAfter string
+	}{
+		{
+			name:         "declared status",
+			err:          apperror.Unauthorized("invalid_token", "token is invalid"),
+			wantResponse: api.GetApiKeys401ApplicationProblemPlusJSONResponse{},
+			wantStatus:   http.StatusUnauthorized,
+			wantCode:     "invalid_token",
+		},
+		{
+			name:           "status with Retry-After",
+			err:            apperror.RateLimited("rate_limited", "slow down", apperror.WithRetryAfter(1500*time.Millisecond)),
+			wantResponse:   api.GetApiKeys429ApplicationProblemPlusJSONResponse{},
+			wantStatus:     http.StatusTooManyRequests,
+			wantCode:       "rate_limited",
+			wantRetryAfter: "2",
+		},
+		{
+			name:         "undeclared status becomes internal error",
+			err:          apperror.Conflict("already_exists", "resource already exists"),
+			wantResponse: api.GetApiKeys500ApplicationProblemPlusJSONResponse{},
+			wantStatus:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := func(context.Context, http.ResponseWriter, *http.Request, interface{}) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api-keys", nil)
+
+			response, err := h.Middleware(next, "GetApiKeys")(r.Context(), w, r, nil)
+			if err != nil {
+				t.Fatalf("Middleware() error = %v", err)
+			}
+
+			object, ok := response.(api.GetApiKeysResponseObject)
+			if !ok || fmt.Sprintf("%T", object) != fmt.Sprintf("%T", tt.wantResponse) {
+				t.Fatalf("Middleware() response = %T, want %T", response, tt.wantResponse)
+			}
+			if err := object.VisitGetApiKeysResponse(w); err != nil {
+				t.Fatalf("VisitGetApiKeysResponse() error = %v", err)
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Retry-After"); tt.wantRetryAfter != "" && got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+
+			var body api.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Status != tt.wantStatus {
+				t.Errorf("body status = %d, want %d", body.Status, tt.wantStatus)
+			}
+			var code string
+			if body.Code != nil {
+				code = *body.Code
+			}
+			if code != tt.wantCode {
+				t.Errorf("body code = %q, want %q", code, tt.wantCode)
+			}
+			if body.Instance == nil || *body.Instance != "/api-keys" {
+				t.Errorf("body instance = %v, want /api-keys", body.Instance)
+			}
+		})
+	}
+}
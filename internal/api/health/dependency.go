@@ -0,0 +1,14 @@
+package health
+
+import (
+	"context"
+
+	"github.com/siyoga/rollstory/pkg/health"
+)
+
+// HealthHandler defines the interface for health business logic
+type HealthHandler interface {
+	Live(ctx context.Context) health.Status
+	Ready(ctx context.Context) health.Status
+	Report(ctx context.Context) health.Report
+}
@@ -0,0 +1,55 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/siyoga/rollstory/internal/generated/api"
+	"github.com/siyoga/rollstory/pkg/health"
+)
+
+// Handler is a thin RPC adapter for health endpoints
+type Handler struct {
+	healthHandler HealthHandler
+}
+
+// NewHandler creates a new RPC health handler
+func NewHandler(healthHandler HealthHandler) *Handler {
+	return &Handler{
+		healthHandler: healthHandler,
+	}
+}
+
+// GetHealthz handles the GET /healthz liveness probe
+func (h *Handler) GetHealthz(ctx context.Context, _ api.GetHealthzRequestObject) (api.GetHealthzResponseObject, error) {
+	return statusResponse(h.healthHandler.Live(ctx)), nil
+}
+
+// GetReadyz handles the GET /readyz readiness probe
+func (h *Handler) GetReadyz(ctx context.Context, _ api.GetReadyzRequestObject) (api.GetReadyzResponseObject, error) {
+	return statusResponse(h.healthHandler.Ready(ctx)), nil
+}
+
+// GetHealth handles the GET /health report. Check errors are only shown on the admin listener.
+func (h *Handler) GetHealth(ctx context.Context, _ api.GetHealthRequestObject) (api.GetHealthResponseObject, error) {
+	return reportResponse(h.healthHandler.Report(ctx)), nil
+}
+
+// statusResponse and reportResponse are rendered by pkg/health,
+// so the public and admin probes answer with the same body
+
+type statusResponse health.Status
+
+func (s statusResponse) VisitGetHealthzResponse(w http.ResponseWriter) error {
+	return health.WriteStatus(w, health.Status(s))
+}
+
+func (s statusResponse) VisitGetReadyzResponse(w http.ResponseWriter) error {
+	return health.WriteStatus(w, health.Status(s))
+}
+
+type reportResponse health.Report
+
+func (r reportResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	return health.WriteReport(w, health.Report(r), false)
+}
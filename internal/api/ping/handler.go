@@ -33,14 +33,8 @@ func (h *Handler) GetPing(ctx context.Context, request api.GetPingRequestObject)
 	// Delegate to app layer (business logic)
 	response, err := h.pingHandler.Handle(ctx)
 	if err != nil {
-		// Delegate error handling
-		if handledErr := h.errorHandler.Handle(ctx, err); handledErr != nil {
-			return api.GetPing500JSONResponse{
-				InternalServerErrorJSONResponse: api.InternalServerErrorJSONResponse{
-					Error: "internal server error",
-				},
-			}, nil
-		}
+		// Delegate error handling, the error is rendered as problem+json by ErrorHandler.Middleware
+		return nil, h.errorHandler.Handle(ctx, err)
 	}
 
 	// Transform app response to API response
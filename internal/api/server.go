@@ -0,0 +1,42 @@
+package api
+
+import (
+	"github.com/siyoga/rollstory/internal/api/apikey"
+	"github.com/siyoga/rollstory/internal/api/auth"
+	"github.com/siyoga/rollstory/internal/api/health"
+	"github.com/siyoga/rollstory/internal/api/ping"
+	"github.com/siyoga/rollstory/internal/generated/api"
+)
+
+var _ api.StrictServerInterface = (*Server)(nil)
+
+// Aliases give embedded handlers distinct field names
+type (
+	PingHandler   = ping.Handler
+	HealthHandler = health.Handler
+	AuthHandler   = auth.Handler
+	APIKeyHandler = apikey.Handler
+)
+
+// Server combines RPC handlers into a single api.StrictServerInterface implementation
+type Server struct {
+	*PingHandler
+	*HealthHandler
+	*AuthHandler
+	*APIKeyHandler
+}
+
+// NewServer creates a new combined RPC server
+func NewServer(
+	pingHandler *ping.Handler,
+	healthHandler *health.Handler,
+	authHandler *auth.Handler,
+	apiKeyHandler *apikey.Handler,
+) *Server {
+	return &Server{
+		PingHandler:   pingHandler,
+		HealthHandler: healthHandler,
+		AuthHandler:   authHandler,
+		APIKeyHandler: apiKeyHandler,
+	}
+}
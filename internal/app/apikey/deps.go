@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+// Repository stores API keys, implemented by psql.APIKeyRepository
+type Repository interface {
+	Create(ctx context.Context, key APIKey) (*APIKey, error)
+	// ListActive returns keys of the user that are not revoked, newest first
+	ListActive(ctx context.Context, userID string) ([]APIKey, error)
+	// GetByPrefix returns the key together with the current role of its owner
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	// Revoke returns postgres.ErrNotFound if the user has no such active key
+	Revoke(ctx context.Context, userID string, id string) error
+	// TouchLastUsed updates last_used_at at most once a minute per key
+	TouchLastUsed(ctx context.Context, id string) error
+	// UseNonce remembers the nonce of a signed request until expiresAt and reports
+	// whether it wasn't seen before. Nonces of the key expired by now are dropped.
+	UseNonce(ctx context.Context, keyID string, nonce string, now time.Time, expiresAt time.Time) (bool, error)
+}
+
+// Sealer is implemented by *pkgAuth.Sealer
+type Sealer interface {
+	Seal(plaintext []byte, associated []byte) ([]byte, error)
+	Open(sealed []byte, associated []byte) ([]byte, error)
+}
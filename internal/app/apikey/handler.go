@@ -0,0 +1,269 @@
+package apikey
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/apperror"
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/logger"
+)
+
+// Handler issues API keys and verifies requests authenticated with them
+type Handler struct {
+	repo    Repository
+	sealer  Sealer
+	maxSkew time.Duration
+	log     *logger.Logger
+	now     func() time.Time
+}
+
+// NewHandler creates a new API key service handler. Request signing is available
+// only when AUTH_API_KEY_ENCRYPTION_KEY is set.
+func NewHandler(repo Repository, cfg pkgAuth.Config, log *logger.Logger) (*Handler, error) {
+	h := &Handler{
+		repo:    repo,
+		maxSkew: cfg.SignatureMaxSkew,
+		log:     log,
+		now:     time.Now,
+	}
+
+	if len(cfg.APIKeyEncryptionKey) > 0 {
+		sealer, err := pkgAuth.NewSealer(cfg.APIKeyEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("api key encryption key: %w", err)
+		}
+
+		h.sealer = sealer
+	}
+
+	return h, nil
+}
+
+// Create issues a key for the user. Keys can't be managed with another key,
+// otherwise a leaked key could mint long-lived replacements for itself.
+func (h *Handler) Create(ctx context.Context, userID string, req CreateRequest) (*Created, error) {
+	if err := h.rejectAPIKeyAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, apperror.Validation("invalid_api_key", "invalid api key", apperror.WithFieldError("name", "must not be empty"))
+	}
+
+	if len(req.Scopes) == 0 {
+		return nil, apperror.Validation("invalid_api_key", "invalid api key", apperror.WithFieldError("scopes", "at least one scope is required"))
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(h.now()) {
+		return nil, apperror.Validation("invalid_api_key", "invalid api key", apperror.WithFieldError("expires_at", "must be in the future"))
+	}
+
+	if req.Signing && h.sealer == nil {
+		return nil, apperror.Validation(
+			"signing_unavailable",
+			"request signing is not enabled on this server",
+			apperror.WithFieldError("signing", "is not available"),
+		)
+	}
+
+	key, prefix, hash, err := pkgAuth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	created := &Created{Key: key}
+
+	var sealed []byte
+	if req.Signing {
+		if created.SigningSecret, err = pkgAuth.NewSigningSecret(); err != nil {
+			return nil, err
+		}
+
+		// the prefix is bound to the ciphertext, so a secret can't be moved to another key
+		if sealed, err = h.sealer.Seal([]byte(created.SigningSecret), []byte(prefix)); err != nil {
+			return nil, fmt.Errorf("seal signing secret: %w", err)
+		}
+	}
+
+	created.APIKey, err = h.repo.Create(ctx, APIKey{
+		UserID:        userID,
+		Name:          req.Name,
+		Prefix:        prefix,
+		KeyHash:       hash,
+		Scopes:        req.Scopes,
+		SigningSecret: sealed,
+		ExpiresAt:     req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	h.log.Info(h.log.WithField(ctx, "api_key_id", created.APIKey.ID), "API key issued")
+
+	return created, nil
+}
+
+// List returns active keys of the user
+func (h *Handler) List(ctx context.Context, userID string) ([]APIKey, error) {
+	if err := h.rejectAPIKeyAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := h.repo.ListActive(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes a key of the user, keys of other users are reported as not found
+func (h *Handler) Revoke(ctx context.Context, userID string, id string) error {
+	if err := h.rejectAPIKeyAuth(ctx); err != nil {
+		return err
+	}
+
+	err := h.repo.Revoke(ctx, userID, id)
+	if errors.Is(err, postgres.ErrNotFound) {
+		return apperror.NotFound("api_key_not_found", "api key not found", apperror.WithCause(err))
+	}
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	h.log.Info(h.log.WithField(ctx, "api_key_id", id), "API key revoked")
+
+	return nil
+}
+
+// VerifyAPIKey authenticates a request carrying the key itself
+func (h *Handler) VerifyAPIKey(ctx context.Context, key string) (*pkgAuth.APIKeyPrincipal, error) {
+	prefix, ok := pkgAuth.ParseAPIKeyPrefix(key)
+	if !ok {
+		return nil, pkgAuth.ErrInvalidAPIKey
+	}
+
+	stored, err := h.usable(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(stored.KeyHash, pkgAuth.HashAPIKey(key)) != 1 {
+		h.rejected(ctx, prefix, "hash mismatch")
+		return nil, pkgAuth.ErrInvalidAPIKey
+	}
+
+	return h.principal(ctx, stored), nil
+}
+
+// VerifySignature authenticates a request signed with the signing secret of the key
+// identified by prefix. base is the auth.SignatureBase of the request. Each nonce is accepted
+// once: it is remembered for as long as the timestamp passes the skew check.
+func (h *Handler) VerifySignature(
+	ctx context.Context,
+	prefix string,
+	timestamp int64,
+	nonce string,
+	base []byte,
+	signature []byte,
+) (*pkgAuth.APIKeyPrincipal, error) {
+	// older captured requests are rejected here, newer ones by their nonce
+	if skew := h.now().Sub(time.Unix(timestamp, 0)).Abs(); skew > h.maxSkew {
+		h.rejected(ctx, prefix, "timestamp outside allowed skew")
+		return nil, pkgAuth.ErrInvalidSignature
+	}
+
+	stored, err := h.usable(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if !stored.Signing() || h.sealer == nil {
+		h.rejected(ctx, prefix, "signing not enabled for key")
+		return nil, pkgAuth.ErrInvalidSignature
+	}
+
+	secret, err := h.sealer.Open(stored.SigningSecret, []byte(stored.Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("open signing secret of api key %s: %w", stored.ID, err)
+	}
+
+	if !hmac.Equal(pkgAuth.Sign(string(secret), base), signature) {
+		h.rejected(ctx, prefix, "signature mismatch")
+		return nil, pkgAuth.ErrInvalidSignature
+	}
+
+	// checked after the signature so unauthenticated requests can't fill the nonce table
+	fresh, err := h.repo.UseNonce(ctx, stored.ID, nonce, h.now(), time.Unix(timestamp, 0).Add(h.maxSkew))
+	if err != nil {
+		return nil, fmt.Errorf("use nonce of api key %s: %w", stored.ID, err)
+	}
+
+	if !fresh {
+		h.rejected(ctx, prefix, "nonce already used")
+		return nil, pkgAuth.ErrInvalidSignature
+	}
+
+	return h.principal(ctx, stored), nil
+}
+
+// usable loads a key that is neither revoked nor expired
+func (h *Handler) usable(ctx context.Context, prefix string) (*APIKey, error) {
+	stored, err := h.repo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, postgres.ErrNotFound) {
+		h.rejected(ctx, prefix, "unknown key")
+		return nil, pkgAuth.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+
+	if stored.RevokedAt != nil {
+		h.rejected(ctx, prefix, "revoked key")
+		return nil, pkgAuth.ErrInvalidAPIKey
+	}
+
+	if stored.ExpiresAt != nil && !stored.ExpiresAt.After(h.now()) {
+		h.rejected(ctx, prefix, "expired key")
+		return nil, pkgAuth.ErrExpiredAPIKey
+	}
+
+	return stored, nil
+}
+
+func (h *Handler) principal(ctx context.Context, key *APIKey) *pkgAuth.APIKeyPrincipal {
+	// last use is informational, a failed update must not reject the request
+	if err := h.repo.TouchLastUsed(ctx, key.ID); err != nil {
+		h.log.Warning(h.log.WithError(h.log.WithField(ctx, "api_key_id", key.ID), err), "Failed to update API key last use")
+	}
+
+	return &pkgAuth.APIKeyPrincipal{
+		KeyID:  key.ID,
+		UserID: key.UserID,
+		Roles:  []string{string(key.OwnerRole)},
+		Scopes: key.Scopes,
+	}
+}
+
+func (h *Handler) rejected(ctx context.Context, prefix string, reason string) {
+	h.log.Info(h.log.WithFields(ctx, map[string]interface{}{
+		"api_key_prefix": prefix,
+		"reason":         reason,
+	}), "API key rejected")
+}
+
+func (h *Handler) rejectAPIKeyAuth(ctx context.Context) error {
+	if _, ok := pkgAuth.APIKeyIDFromContext(ctx); ok {
+		return apperror.Forbidden("api_key_not_allowed", "api keys can't be managed with an api key")
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/policy"
+)
+
+// APIKey is a stored API key. Only the hash of the key is kept, the signing secret is sealed.
+type APIKey struct {
+	ID            string
+	UserID        string
+	Name          string
+	Prefix        string
+	KeyHash       []byte
+	Scopes        []string
+	SigningSecret []byte
+	ExpiresAt     *time.Time
+	LastUsedAt    *time.Time
+	CreatedAt     time.Time
+	RevokedAt     *time.Time
+
+	// OwnerRole is the current role of the key owner, loaded together with the key
+	OwnerRole policy.Role
+}
+
+// Signing reports whether requests can be signed with the key
+func (k *APIKey) Signing() bool {
+	return len(k.SigningSecret) > 0
+}
+
+// CreateRequest describes a key to issue
+type CreateRequest struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+	Signing   bool
+}
+
+// Created is a newly issued key. Key and SigningSecret are shown to the client only once.
+type Created struct {
+	APIKey        *APIKey
+	Key           string
+	SigningSecret string
+}
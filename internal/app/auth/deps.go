@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// UserRepository stores user accounts, implemented by psql.UserRepository
+type UserRepository interface {
+	Create(ctx context.Context, email string, passwordHash string) (*User, error)
+	GetByID(ctx context.Context, id string) (*User, error)
+	// GetByEmail matches the email case-insensitively
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	UpdatePasswordHash(ctx context.Context, id string, passwordHash string) error
+}
+
+// RefreshTokenRepository stores hashed refresh tokens, implemented by psql.RefreshTokenRepository
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token RefreshToken) (*RefreshToken, error)
+	GetByHash(ctx context.Context, hash []byte) (*RefreshToken, error)
+	// GetByHashForUpdate locks the token row until the transaction in ctx ends
+	GetByHashForUpdate(ctx context.Context, hash []byte) (*RefreshToken, error)
+	// Replace revokes the token and links it to the token issued in its place
+	Replace(ctx context.Context, id string, replacedBy string) error
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+// PasswordHasher is implemented by *pkgAuth.PasswordHasher
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password string, encoded string) (bool, error)
+	NeedsRehash(encoded string) bool
+}
+
+// TokenIssuer is implemented by *pkgAuth.TokenIssuer
+type TokenIssuer interface {
+	Issue(subject string, roles ...string) (pkgAuth.AccessToken, error)
+}
+
+// SessionManager is implemented by *session.Manager
+type SessionManager interface {
+	Create(ctx context.Context, userID string) (*session.Session, http.Cookie, error)
+	Destroy(ctx context.Context, id string) (http.Cookie, error)
+}
+
+// TxManager is implemented by *postgres.TxManager
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error, opts ...postgres.TxOption) error
+}
@@ -0,0 +1,360 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/siyoga/rollstory/pkg/apperror"
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+const minPasswordLength = 8
+
+// Handler contains business logic for registration, login and token rotation
+type Handler struct {
+	users         UserRepository
+	refreshTokens RefreshTokenRepository
+	hasher        PasswordHasher
+	issuer        TokenIssuer
+	tx            TxManager
+	sessions      SessionManager
+	refreshTTL    time.Duration
+	log           *logger.Logger
+
+	// dummyHash is verified when the email is unknown, so login takes the same time
+	// whether or not the account exists
+	dummyOnce sync.Once
+	dummyHash string
+}
+
+// NewHandler creates a new auth service handler
+func NewHandler(
+	users UserRepository,
+	refreshTokens RefreshTokenRepository,
+	hasher PasswordHasher,
+	issuer TokenIssuer,
+	tx TxManager,
+	sessions SessionManager,
+	cfg pkgAuth.Config,
+	log *logger.Logger,
+) *Handler {
+	return &Handler{
+		users:         users,
+		refreshTokens: refreshTokens,
+		hasher:        hasher,
+		issuer:        issuer,
+		tx:            tx,
+		sessions:      sessions,
+		refreshTTL:    cfg.RefreshTokenTTL,
+		log:           log,
+	}
+}
+
+// Register creates an account, the email must not be taken
+func (h *Handler) Register(ctx context.Context, email string, password string) (*User, error) {
+	email = normalizeEmail(email)
+	if len(password) < minPasswordLength {
+		return nil, apperror.Validation(
+			"weak_password",
+			"password is too short",
+			apperror.WithFieldError("password", fmt.Sprintf("must be at least %d characters", minPasswordLength)),
+		)
+	}
+
+	hash, err := h.hasher.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+
+	user, err := h.users.Create(ctx, email, hash)
+	if errors.Is(err, postgres.ErrUniqueViolation) {
+		return nil, apperror.Conflict("email_taken", "email is already registered", apperror.WithCause(err))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	h.log.Info(h.log.WithField(ctx, "user_id", user.ID), "User registered")
+
+	return user, nil
+}
+
+// Login checks credentials and starts a new refresh token family
+func (h *Handler) Login(ctx context.Context, email string, password string) (*Tokens, error) {
+	user, err := h.authenticate(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = h.log.WithField(ctx, "user_id", user.ID)
+
+	tokens, _, err := h.issue(ctx, user, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Info(ctx, "User logged in")
+
+	return tokens, nil
+}
+
+// StartSession checks credentials and starts a cookie session for browser clients
+func (h *Handler) StartSession(ctx context.Context, email string, password string) (*SessionLogin, error) {
+	user, err := h.authenticate(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	s, cookie, err := h.sessions.Create(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+	s.Roles = []string{string(user.Role)}
+
+	h.log.Info(h.log.WithFields(ctx, map[string]interface{}{
+		"user_id":    user.ID,
+		"session_id": s.ID,
+	}), "Session started")
+
+	return &SessionLogin{User: user, Session: s, Cookie: cookie}, nil
+}
+
+// CurrentSession returns the session the request is authenticated with and its user
+func (h *Handler) CurrentSession(ctx context.Context) (*User, *session.Session, error) {
+	s, ok := session.FromContext(ctx)
+	if !ok {
+		return nil, nil, errSessionRequired()
+	}
+
+	user, err := h.Me(ctx, s.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, s, nil
+}
+
+// EndSession destroys the session the request is authenticated with and returns
+// the cookie that removes it from the browser
+func (h *Handler) EndSession(ctx context.Context) (http.Cookie, error) {
+	s, ok := session.FromContext(ctx)
+	if !ok {
+		return http.Cookie{}, errSessionRequired()
+	}
+
+	cookie, err := h.sessions.Destroy(ctx, s.ID)
+	if err != nil {
+		return http.Cookie{}, fmt.Errorf("destroy session: %w", err)
+	}
+
+	h.log.Info(ctx, "Session ended")
+
+	return cookie, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token is single-use:
+// presenting a rotated token again means it leaked, and its whole family is revoked.
+func (h *Handler) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	var (
+		tokens *Tokens
+		reused *RefreshToken
+	)
+
+	err := h.tx.Do(ctx, func(ctx context.Context) error {
+		tokens, reused = nil, nil
+
+		stored, err := h.refreshTokens.GetByHashForUpdate(ctx, pkgAuth.HashRefreshToken(refreshToken))
+		if errors.Is(err, postgres.ErrNotFound) {
+			return errInvalidRefreshToken()
+		}
+		if err != nil {
+			return fmt.Errorf("get refresh token: %w", err)
+		}
+
+		if stored.RevokedAt != nil {
+			// the revocation must be committed, so reuse is reported after the transaction
+			reused = stored
+			return h.refreshTokens.RevokeFamily(ctx, stored.FamilyID)
+		}
+
+		if !stored.ExpiresAt.After(time.Now()) {
+			return errInvalidRefreshToken()
+		}
+
+		// the role is read again, so role changes take effect on the next refresh
+		user, err := h.users.GetByID(ctx, stored.UserID)
+		if errors.Is(err, postgres.ErrNotFound) {
+			// the token outlived the account
+			return errInvalidRefreshToken()
+		}
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		var next *RefreshToken
+		tokens, next, err = h.issue(ctx, user, stored.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		return h.refreshTokens.Replace(ctx, stored.ID, next.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused != nil {
+		reuseCtx := h.log.WithFields(ctx, map[string]interface{}{
+			"user_id":          reused.UserID,
+			"token_family_id":  reused.FamilyID,
+			"refresh_token_id": reused.ID,
+		})
+		h.log.Warning(reuseCtx, "Revoked refresh token reused, token family revoked")
+
+		return nil, errInvalidRefreshToken()
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes the refresh token family of the session. Unknown tokens and tokens
+// of other users are ignored, so the response doesn't reveal whether a token exists.
+func (h *Handler) Logout(ctx context.Context, userID string, refreshToken string) error {
+	stored, err := h.refreshTokens.GetByHash(ctx, pkgAuth.HashRefreshToken(refreshToken))
+	if errors.Is(err, postgres.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get refresh token: %w", err)
+	}
+
+	if stored.UserID != userID {
+		return nil
+	}
+
+	if err := h.refreshTokens.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("revoke refresh tokens: %w", err)
+	}
+
+	h.log.Info(ctx, "User logged out")
+
+	return nil
+}
+
+// Me returns the account of the authenticated user
+func (h *Handler) Me(ctx context.Context, userID string) (*User, error) {
+	user, err := h.users.GetByID(ctx, userID)
+	if errors.Is(err, postgres.ErrNotFound) {
+		// the token outlived the account
+		return nil, apperror.Unauthorized("user_not_found", "account no longer exists", apperror.WithCause(err))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// authenticate checks credentials, unknown emails and wrong passwords are reported the same way
+func (h *Handler) authenticate(ctx context.Context, email string, password string) (*User, error) {
+	user, err := h.users.GetByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, postgres.ErrNotFound) {
+		_, _ = h.hasher.Verify(password, h.dummy())
+		return nil, errInvalidCredentials()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	ok, err := h.hasher.Verify(password, user.PasswordHash)
+	if err != nil {
+		return nil, fmt.Errorf("verify password: %w", err)
+	}
+	if !ok {
+		return nil, errInvalidCredentials()
+	}
+
+	// hashing parameters changed since the password was set, the plain password is only known now
+	if h.hasher.NeedsRehash(user.PasswordHash) {
+		if err := h.rehash(ctx, user.ID, password); err != nil {
+			h.log.Warning(h.log.WithError(h.log.WithField(ctx, "user_id", user.ID), err), "Failed to rehash password")
+		}
+	}
+
+	return user, nil
+}
+
+// issue creates an access token and a refresh token in the given family
+func (h *Handler) issue(ctx context.Context, user *User, familyID string) (*Tokens, *RefreshToken, error) {
+	access, err := h.issuer.Issue(user.ID, string(user.Role))
+	if err != nil {
+		return nil, nil, fmt.Errorf("issue access token: %w", err)
+	}
+
+	token, hash, err := pkgAuth.NewRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := h.refreshTokens.Create(ctx, RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		Hash:      hash,
+		ExpiresAt: time.Now().Add(h.refreshTTL),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("store refresh token: %w", err)
+	}
+
+	return &Tokens{
+		Access:           access,
+		RefreshToken:     token,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, stored, nil
+}
+
+func (h *Handler) rehash(ctx context.Context, userID string, password string) error {
+	hash, err := h.hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	return h.users.UpdatePasswordHash(ctx, userID, hash)
+}
+
+func (h *Handler) dummy() string {
+	h.dummyOnce.Do(func() {
+		hash, err := h.hasher.Hash(uuid.NewString())
+		if err == nil {
+			h.dummyHash = hash
+		}
+	})
+
+	return h.dummyHash
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func errInvalidCredentials() error {
+	return apperror.Unauthorized("invalid_credentials", "invalid email or password")
+}
+
+func errInvalidRefreshToken() error {
+	return apperror.Unauthorized("invalid_refresh_token", "refresh token is invalid or expired")
+}
+
+func errSessionRequired() error {
+	return apperror.Unauthorized("session_required", "the request is not authenticated with a session cookie")
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/policy"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// User is a registered account
+type User struct {
+	ID           string
+	Email        string
+	PasswordHash string
+	Role         policy.Role
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RefreshToken is a stored refresh token. Tokens issued by rotating one another share FamilyID,
+// so a leaked token can be revoked together with everything issued after it.
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	Hash       []byte
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *string
+}
+
+// Tokens is the result of a successful login or refresh
+type Tokens struct {
+	Access           pkgAuth.AccessToken
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// SessionLogin is the result of a successful browser login, Cookie must be sent to the client
+type SessionLogin struct {
+	User    *User
+	Session *session.Session
+	Cookie  http.Cookie
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/policy"
+)
+
+// NewPolicy describes what each role may do, anything not granted here is denied.
+// API operations declare the permissions they need in x-permissions of api/schema.yaml,
+// handlers check ownership of concrete resources with policy.Engine.Authorize.
+func NewPolicy(log *logger.Logger) *policy.Engine {
+	return policy.New(
+		policy.WithLogger(log),
+		policy.WithRole(policy.RoleGameMaster,
+			policy.Allow("account:*", "campaign:*", "session:*", "character:*"),
+		),
+		policy.WithRole(policy.RolePlayer,
+			policy.Allow("account:*", "campaign:read", "session:read", "character:read"),
+			// players edit only their own characters
+			policy.AllowOwn("character:*"),
+		),
+		policy.WithRole(policy.RoleSpectator,
+			policy.Allow("account:*", "campaign:read", "session:read", "character:read"),
+		),
+	)
+}
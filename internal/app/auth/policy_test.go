@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/policy"
+)
+
+func TestNewPolicy(t *testing.T) {
+	log, err := logger.New(logger.WithConfig(logger.Config{Level: "ERROR", Output: "stderr", Format: "json"}))
+	if err != nil {
+		t.Fatalf("logger.New() error = %v", err)
+	}
+
+	engine := NewPolicy(log)
+
+	own := &policy.Resource{Type: "character", ID: "c1", OwnerID: "u1"}
+	foreign := &policy.Resource{Type: "character", ID: "c2", OwnerID: "u2"}
+
+	tests := []struct {
+		name                  string
+		role                  policy.Role
+		permission            policy.Permission
+		resource              *policy.Resource
+		wantAllowed           bool
+		wantRequiresOwnership bool
+	}{
+		{name: "game master reads account", role: policy.RoleGameMaster, permission: "account:read", wantAllowed: true},
+		{name: "game master writes campaign", role: policy.RoleGameMaster, permission: "campaign:write", wantAllowed: true},
+		{name: "game master deletes session", role: policy.RoleGameMaster, permission: "session:delete", wantAllowed: true},
+		{name: "game master edits foreign character", role: policy.RoleGameMaster, permission: "character:write", resource: foreign, wantAllowed: true},
+		{name: "game master has no unknown resources", role: policy.RoleGameMaster, permission: "billing:read"},
+
+		{name: "player writes account", role: policy.RolePlayer, permission: "account:write", wantAllowed: true},
+		{name: "player reads campaign", role: policy.RolePlayer, permission: "campaign:read", wantAllowed: true},
+		{name: "player can't write campaign", role: policy.RolePlayer, permission: "campaign:write"},
+		{name: "player can't write session", role: policy.RolePlayer, permission: "session:write"},
+		{name: "player reads any character", role: policy.RolePlayer, permission: "character:read", resource: foreign, wantAllowed: true},
+		{name: "player writes characters pending ownership", role: policy.RolePlayer, permission: "character:write", wantAllowed: true, wantRequiresOwnership: true},
+		{name: "player writes own character", role: policy.RolePlayer, permission: "character:write", resource: own, wantAllowed: true},
+		{name: "player can't write foreign character", role: policy.RolePlayer, permission: "character:write", resource: foreign},
+
+		{name: "spectator writes account", role: policy.RoleSpectator, permission: "account:write", wantAllowed: true},
+		{name: "spectator reads session", role: policy.RoleSpectator, permission: "session:read", wantAllowed: true},
+		{name: "spectator can't write own character", role: policy.RoleSpectator, permission: "character:write", resource: own},
+		{name: "spectator can't write campaign", role: policy.RoleSpectator, permission: "campaign:write"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := engine.Evaluate(context.Background(), policy.Request{
+				Subject:    policy.Subject{ID: "u1", Roles: []policy.Role{tt.role}},
+				Permission: tt.permission,
+				Resource:   tt.resource,
+			})
+
+			if d.Allowed != tt.wantAllowed || d.RequiresOwnership != tt.wantRequiresOwnership {
+				t.Errorf("Evaluate() = %+v, want allowed %v, requires ownership %v", d, tt.wantAllowed, tt.wantRequiresOwnership)
+			}
+		})
+	}
+}
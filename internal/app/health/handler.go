@@ -0,0 +1,51 @@
+package health
+
+import (
+	"context"
+
+	"github.com/siyoga/rollstory/pkg/health"
+	"github.com/siyoga/rollstory/pkg/logger"
+)
+
+// Handler contains business logic for health probes
+type Handler struct {
+	registry *health.Registry
+	log      *logger.Logger
+}
+
+// NewHandler creates a new health service handler
+func NewHandler(registry *health.Registry, log *logger.Logger) *Handler {
+	return &Handler{
+		registry: registry,
+		log:      log,
+	}
+}
+
+// Live reports whether the process is alive
+func (h *Handler) Live(ctx context.Context) health.Status {
+	return h.registry.Live(ctx)
+}
+
+// Ready reports whether the service can accept traffic
+func (h *Handler) Ready(ctx context.Context) health.Status {
+	return h.registry.Ready(ctx)
+}
+
+// Report runs all registered checks and logs the failed ones
+func (h *Handler) Report(ctx context.Context) health.Report {
+	report := h.registry.Run(ctx)
+
+	for _, res := range report.Checks {
+		if res.Err == nil {
+			continue
+		}
+
+		checkCtx := h.log.WithFields(ctx, map[string]interface{}{
+			"check":    res.Name,
+			"critical": res.Critical,
+		})
+		h.log.Warning(h.log.WithError(checkCtx, res.Err), "Health check failed")
+	}
+
+	return report
+}
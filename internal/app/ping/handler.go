@@ -25,12 +25,7 @@ func NewHandler(log *logger.Logger) *Handler {
 
 // Handle executes the ping business logic
 func (h *Handler) Handle(ctx context.Context) (*Response, error) {
-	// Business logic goes here
-	// For now, it's simple, but in real app this could involve:
-	// - checking database connectivity
-	// - verifying external service availability
-	// - running health checks
-
+	// Dependency checks live in the health handler, ping only confirms the service responds
 	h.log.Debug(ctx, "Ping request processed")
 
 	return &Response{
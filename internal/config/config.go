@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/env"
+	"github.com/siyoga/rollstory/pkg/health"
+	"github.com/siyoga/rollstory/pkg/http/admin"
+	"github.com/siyoga/rollstory/pkg/http/listener"
+	"github.com/siyoga/rollstory/pkg/http/openapi"
+	"github.com/siyoga/rollstory/pkg/http/router/middleware"
+	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// EnvironmentProduction disables development-only checks such as response validation
+const EnvironmentProduction = "production"
+
+// Config aggregates configuration of every service component
+type Config struct {
+	Environment string `env:"ENVIRONMENT" default:"development"`
+	// MigrateOnStart applies pending migrations before the server starts
+	MigrateOnStart bool `env:"MIGRATE_ON_START" default:"false"`
+
+	Admin    admin.Config
+	Auth     auth.Config
+	Docs     openapi.Config
+	Health   health.Config
+	HTTP     middleware.Config
+	Listener listener.Config
+	Logger   logger.Config
+	Postgres postgres.Config `prefix:"PG_"`
+	Session  session.Config
+}
+
+// Load reads configuration from defaults, an optional config file (CONFIG_FILE or -config),
+// environment variables and command-line flags, in increasing order of precedence
+func Load(args []string) (*Config, error) {
+	cfg := &Config{}
+
+	if err := env.Load(
+		cfg,
+		env.WithFile(os.Getenv("CONFIG_FILE")),
+		env.WithArgs(args),
+	); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return cfg, nil
+}
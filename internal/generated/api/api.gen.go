@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
@@ -23,37 +24,295 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Error string `json:"error"`
+const (
+	ApiKeyAuthScopes    = "apiKeyAuth.Scopes"
+	BearerAuthScopes    = "bearerAuth.Scopes"
+	SessionCookieScopes = "sessionCookie.Scopes"
+)
+
+// Defines values for HealthState.
+const (
+	Degraded HealthState = "degraded"
+	Fail     HealthState = "fail"
+	Ok       HealthState = "ok"
+)
+
+// Defines values for Role.
+const (
+	GameMaster Role = "game_master"
+	Player     Role = "player"
+	Spectator  Role = "spectator"
+)
+
+// Defines values for TokenPairTokenType.
+const (
+	Bearer TokenPairTokenType = "Bearer"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         UUID       `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix Начало ключа, по нему ключ можно узнать
+	Prefix  string       `json:"prefix"`
+	Scopes  []Permission `json:"scopes"`
+	Signing bool         `json:"signing"`
+}
+
+// APIKeyList defines model for APIKeyList.
+type APIKeyList struct {
+	Items []APIKey `json:"items"`
+}
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// Scopes Ключ получает только эти разрешения и только в пределах роли владельца
+	Scopes []Permission `json:"scopes"`
+
+	// Signing Выпустить секрет для подписи запросов HMAC
+	Signing *bool `json:"signing,omitempty"`
+}
+
+// CreatedAPIKey defines model for CreatedAPIKey.
+type CreatedAPIKey struct {
+	ApiKey APIKey `json:"api_key"`
+
+	// Key Ключ целиком, сервер хранит только его хеш
+	Key string `json:"key"`
+
+	// SigningSecret Секрет подписи запросов, если он запрошен
+	SigningSecret *string `json:"signing_secret,omitempty"`
+}
+
+// Credentials defines model for Credentials.
+type Credentials struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	Critical   bool  `json:"critical"`
+	DurationMs int64 `json:"duration_ms"`
+
+	// Error Текст ошибки проверки, только в отчете служебного порта
+	Error *string `json:"error,omitempty"`
+	Name  string  `json:"name"`
+
+	// Status ok - все проверки успешны, degraded - упали только некритичные проверки,
+	// fail - упала критичная проверка или сервис завершает работу
+	Status HealthState `json:"status"`
+}
+
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	Checks []HealthCheck `json:"checks"`
+
+	// Status ok - все проверки успешны, degraded - упали только некритичные проверки,
+	// fail - упала критичная проверка или сервис завершает работу
+	Status HealthState `json:"status"`
+}
+
+// HealthState ok - все проверки успешны, degraded - упали только некритичные проверки,
+// fail - упала критичная проверка или сервис завершает работу
+type HealthState string
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus struct {
+	// Status ok - все проверки успешны, degraded - упали только некритичные проверки,
+	// fail - упала критичная проверка или сервис завершает работу
+	Status HealthState `json:"status"`
 }
 
+// Permission Разрешение "<ресурс>:<действие>", "*" заменяет ресурс, действие или разрешение целиком
+type Permission = string
+
 // PingResponse Успешное сообщение "Pong"
 type PingResponse struct {
 	// Message Поле с сообщением
 	Message string `json:"message"`
 }
 
+// Problem Описание ошибки в формате RFC 9457 (application/problem+json)
+type Problem struct {
+	// Code Машиночитаемый код ошибки предметной области
+	Code *string `json:"code,omitempty"`
+
+	// Detail Описание конкретного вхождения ошибки
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Ошибки отдельных полей, параметров или заголовков запроса
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// IncidentId Идентификатор внутренней ошибки для поиска в логах
+	IncidentId *string `json:"incident_id,omitempty"`
+
+	// Instance Путь запроса, на котором произошла ошибка
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestId Идентификатор запроса из заголовка X-Request-Id
+	RequestId *string `json:"request_id,omitempty"`
+
+	// Status HTTP статус ответа
+	Status int `json:"status"`
+
+	// Title Краткое описание вида ошибки
+	Title string `json:"title"`
+
+	// Type URI вида ошибки, about:blank если вид определяется только статусом
+	Type string `json:"type"`
+}
+
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Role Роль пользователя, определяет разрешения по политике доступа
+type Role string
+
+// Session defines model for Session.
+type Session struct {
+	// CsrfToken Передается в заголовке X-CSRF-Token во всех изменяющих запросах сессии
+	CsrfToken string    `json:"csrf_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      User      `json:"user"`
+}
+
+// TokenPair defines model for TokenPair.
+type TokenPair struct {
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Время жизни access токена в секундах
+	ExpiresIn int `json:"expires_in"`
+
+	// RefreshExpiresIn Время жизни refresh токена в секундах
+	RefreshExpiresIn int                `json:"refresh_expires_in"`
+	RefreshToken     string             `json:"refresh_token"`
+	TokenType        TokenPairTokenType `json:"token_type"`
+}
+
+// TokenPairTokenType defines model for TokenPair.TokenType.
+type TokenPairTokenType string
+
 // UUID defines model for UUID.
 type UUID = openapi_types.UUID
 
-// XUserId defines model for X-User-Id.
-type XUserId = UUID
+// User defines model for User.
+type User struct {
+	CreatedAt time.Time `json:"created_at"`
+	Email     string    `json:"email"`
+	Id        UUID      `json:"id"`
 
-// InternalServerError defines model for InternalServerError.
-type InternalServerError = ErrorResponse
-
-// GetPingParams defines parameters for GetPing.
-type GetPingParams struct {
-	// XUserId Идентификатор пользователя (UUID)
-	XUserId *XUserId `json:"X-User-Id,omitempty"`
+	// Role Роль пользователя, определяет разрешения по политике доступа
+	Role Role `json:"role"`
 }
 
+// BadRequest Описание ошибки в формате RFC 9457 (application/problem+json)
+type BadRequest = Problem
+
+// Conflict Описание ошибки в формате RFC 9457 (application/problem+json)
+type Conflict = Problem
+
+// Forbidden Описание ошибки в формате RFC 9457 (application/problem+json)
+type Forbidden = Problem
+
+// InternalServerError Описание ошибки в формате RFC 9457 (application/problem+json)
+type InternalServerError = Problem
+
+// NotFound Описание ошибки в формате RFC 9457 (application/problem+json)
+type NotFound = Problem
+
+// PayloadTooLarge Описание ошибки в формате RFC 9457 (application/problem+json)
+type PayloadTooLarge = Problem
+
+// ServiceUnavailable Описание ошибки в формате RFC 9457 (application/problem+json)
+type ServiceUnavailable = Problem
+
+// TooManyRequests Описание ошибки в формате RFC 9457 (application/problem+json)
+type TooManyRequests = Problem
+
+// Unauthorized Описание ошибки в формате RFC 9457 (application/problem+json)
+type Unauthorized = Problem
+
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody = CreateAPIKeyRequest
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = Credentials
+
+// PostAuthLogoutJSONRequestBody defines body for PostAuthLogout for application/json ContentType.
+type PostAuthLogoutJSONRequestBody = RefreshRequest
+
+// PostAuthRefreshJSONRequestBody defines body for PostAuthRefresh for application/json ContentType.
+type PostAuthRefreshJSONRequestBody = RefreshRequest
+
+// PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
+type PostAuthRegisterJSONRequestBody = Credentials
+
+// PostAuthSessionJSONRequestBody defines body for PostAuthSession for application/json ContentType.
+type PostAuthSessionJSONRequestBody = Credentials
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Действующие API ключи текущего пользователя
+	// (GET /api-keys)
+	GetApiKeys(w http.ResponseWriter, r *http.Request)
+	// Выпуск API ключа, ключ и секрет подписи возвращаются только в этом ответе
+	// (POST /api-keys)
+	PostApiKeys(w http.ResponseWriter, r *http.Request)
+	// Отзыв API ключа
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id UUID)
+	// Вход по email и паролю, выдает access и refresh токены
+	// (POST /auth/login)
+	PostAuthLogin(w http.ResponseWriter, r *http.Request)
+	// Выход, отзывает refresh токен и все токены, выпущенные из него
+	// (POST /auth/logout)
+	PostAuthLogout(w http.ResponseWriter, r *http.Request)
+	// Текущий пользователь
+	// (GET /auth/me)
+	GetAuthMe(w http.ResponseWriter, r *http.Request)
+	// Обмен refresh токена на новую пару, старый refresh токен становится недействительным
+	// (POST /auth/refresh)
+	PostAuthRefresh(w http.ResponseWriter, r *http.Request)
+	// Регистрация пользователя по email и паролю
+	// (POST /auth/register)
+	PostAuthRegister(w http.ResponseWriter, r *http.Request)
+	// Выход, завершает сессию и удаляет cookie
+	// (DELETE /auth/session)
+	DeleteAuthSession(w http.ResponseWriter, r *http.Request)
+	// Текущая сессия и ее CSRF токен
+	// (GET /auth/session)
+	GetAuthSession(w http.ResponseWriter, r *http.Request)
+	// Вход для браузерных клиентов, начинает сессию в cookie
+	// (POST /auth/session)
+	PostAuthSession(w http.ResponseWriter, r *http.Request)
+	// Подробный отчет о состоянии всех зависимостей
+	// (GET /health)
+	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Liveness проба, процесс запущен и обрабатывает запросы
+	// (GET /healthz)
+	GetHealthz(w http.ResponseWriter, r *http.Request)
 	// Ping команда для проверки сервиса
 	// (GET /ping)
-	GetPing(w http.ResponseWriter, r *http.Request, params GetPingParams)
+	GetPing(w http.ResponseWriter, r *http.Request)
+	// Readiness проба, критичные зависимости доступны и сервис принимает трафик
+	// (GET /readyz)
+	GetReadyz(w http.ResponseWriter, r *http.Request)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -65,37 +324,41 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// GetPing operation middleware
-func (siw *ServerInterfaceWrapper) GetPing(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(w http.ResponseWriter, r *http.Request) {
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPingParams
+	ctx := r.Context()
 
-	headers := r.Header
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	// ------------- Optional header parameter "X-User-Id" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-User-Id")]; found {
-		var XUserId XUserId
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-User-Id", Count: n})
-			return
-		}
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-		err = runtime.BindStyledParameterWithOptions("simple", "X-User-Id", valueList[0], &XUserId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-User-Id", Err: err})
-			return
-		}
+	r = r.WithContext(ctx)
 
-		params.XUserId = &XUserId
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiKeys(w, r)
+	}))
 
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPing(w, r, params)
+		siw.Handler.PostApiKeys(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -105,180 +368,1676 @@ func (siw *ServerInterfaceWrapper) GetPing(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// DeleteApiKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiKeysId(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "id" -------------
+	var id UUID
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	r = r.WithContext(ctx)
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiKeysId(w, r, id)
+	}))
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r)
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogin(w, r)
+	}))
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+	handler.ServeHTTP(w, r)
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+// PostAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+	ctx := r.Context()
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	r = r.WithContext(ctx)
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogout(w, r)
+	}))
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+// GetAuthMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthMe(w http.ResponseWriter, r *http.Request) {
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+	ctx := r.Context()
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	m.HandleFunc("GET "+options.BaseURL+"/ping", wrapper.GetPing)
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
-	return m
-}
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
 
-type InternalServerErrorJSONResponse ErrorResponse
+	r = r.WithContext(ctx)
 
-type GetPingRequestObject struct {
-	Params GetPingParams
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthMe(w, r)
+	}))
 
-type GetPingResponseObject interface {
-	VisitGetPingResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetPing200JSONResponse PingResponse
+// PostAuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPing200JSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRefresh(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetPing500JSONResponse struct {
-	InternalServerErrorJSONResponse
+	handler.ServeHTTP(w, r)
 }
 
-func (response GetPing500JSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// PostAuthRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRegister(w, r)
+	}))
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Ping команда для проверки сервиса
-	// (GET /ping)
-	GetPing(ctx context.Context, request GetPingRequestObject) (GetPingResponseObject, error)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// DeleteAuthSession operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthSession(w http.ResponseWriter, r *http.Request) {
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	ctx := r.Context()
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAuthSession(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthSession operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthSession(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, SessionCookieScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthSession(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthSession operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthSession(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthSession(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealthz operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthz(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPing operation middleware
+func (siw *ServerInterfaceWrapper) GetPing(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPing(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReadyz operation middleware
+func (siw *ServerInterfaceWrapper) GetReadyz(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadyz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/api-keys", wrapper.GetApiKeys)
+	m.HandleFunc("POST "+options.BaseURL+"/api-keys", wrapper.PostApiKeys)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api-keys/{id}", wrapper.DeleteApiKeysId)
+	m.HandleFunc("POST "+options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
+	m.HandleFunc("POST "+options.BaseURL+"/auth/logout", wrapper.PostAuthLogout)
+	m.HandleFunc("GET "+options.BaseURL+"/auth/me", wrapper.GetAuthMe)
+	m.HandleFunc("POST "+options.BaseURL+"/auth/refresh", wrapper.PostAuthRefresh)
+	m.HandleFunc("POST "+options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
+	m.HandleFunc("DELETE "+options.BaseURL+"/auth/session", wrapper.DeleteAuthSession)
+	m.HandleFunc("GET "+options.BaseURL+"/auth/session", wrapper.GetAuthSession)
+	m.HandleFunc("POST "+options.BaseURL+"/auth/session", wrapper.PostAuthSession)
+	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.GetHealth)
+	m.HandleFunc("GET "+options.BaseURL+"/healthz", wrapper.GetHealthz)
+	m.HandleFunc("GET "+options.BaseURL+"/ping", wrapper.GetPing)
+	m.HandleFunc("GET "+options.BaseURL+"/readyz", wrapper.GetReadyz)
+
+	return m
+}
+
+type BadRequestApplicationProblemPlusJSONResponse Problem
+
+type ConflictApplicationProblemPlusJSONResponse Problem
+
+type ForbiddenApplicationProblemPlusJSONResponse Problem
+
+type InternalServerErrorApplicationProblemPlusJSONResponse Problem
+
+type NotFoundApplicationProblemPlusJSONResponse Problem
+
+type PayloadTooLargeApplicationProblemPlusJSONResponse Problem
+
+type ServiceUnavailableResponseHeaders struct {
+	RetryAfter int
+}
+type ServiceUnavailableApplicationProblemPlusJSONResponse struct {
+	Body Problem
+
+	Headers ServiceUnavailableResponseHeaders
+}
+
+type TooManyRequestsResponseHeaders struct {
+	RetryAfter int
+}
+type TooManyRequestsApplicationProblemPlusJSONResponse struct {
+	Body Problem
+
+	Headers TooManyRequestsResponseHeaders
+}
+
+type UnauthorizedApplicationProblemPlusJSONResponse Problem
+
+type GetApiKeysRequestObject struct {
+}
+
+type GetApiKeysResponseObject interface {
+	VisitGetApiKeysResponse(w http.ResponseWriter) error
+}
+
+type GetApiKeys200JSONResponse APIKeyList
+
+func (response GetApiKeys200JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetApiKeys401ApplicationProblemPlusJSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetApiKeys403ApplicationProblemPlusJSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetApiKeys429ApplicationProblemPlusJSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiKeys500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetApiKeys500ApplicationProblemPlusJSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetApiKeys503ApplicationProblemPlusJSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostApiKeysRequestObject struct {
+	Body *PostApiKeysJSONRequestBody
+}
+
+type PostApiKeysResponseObject interface {
+	VisitPostApiKeysResponse(w http.ResponseWriter) error
+}
+
+type PostApiKeys201JSONResponse CreatedAPIKey
+
+func (response PostApiKeys201JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys400ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys401ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys403ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys413ApplicationProblemPlusJSONResponse struct {
+	PayloadTooLargeApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys413ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys429ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostApiKeys500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys500ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response PostApiKeys503ApplicationProblemPlusJSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteApiKeysIdRequestObject struct {
+	Id UUID `json:"id"`
+}
+
+type DeleteApiKeysIdResponseObject interface {
+	VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiKeysId204Response struct {
+}
+
+func (response DeleteApiKeysId204Response) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteApiKeysId400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId400ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId401ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId403ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId404ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId429ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteApiKeysId500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId500ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteApiKeysId503ApplicationProblemPlusJSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthLoginRequestObject struct {
+	Body *PostAuthLoginJSONRequestBody
+}
+
+type PostAuthLoginResponseObject interface {
+	VisitPostAuthLoginResponse(w http.ResponseWriter) error
+}
+
+type PostAuthLogin200JSONResponse TokenPair
+
+func (response PostAuthLogin200JSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin400ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin401ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin403ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin413ApplicationProblemPlusJSONResponse struct {
+	PayloadTooLargeApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin413ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin429ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthLogin500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin500ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogin503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogin503ApplicationProblemPlusJSONResponse) VisitPostAuthLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthLogoutRequestObject struct {
+	Body *PostAuthLogoutJSONRequestBody
+}
+
+type PostAuthLogoutResponseObject interface {
+	VisitPostAuthLogoutResponse(w http.ResponseWriter) error
+}
+
+type PostAuthLogout204Response struct {
+}
+
+func (response PostAuthLogout204Response) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostAuthLogout400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout400ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout401ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout403ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout413ApplicationProblemPlusJSONResponse struct {
+	PayloadTooLargeApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout413ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout429ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthLogout500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout500ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthLogout503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthLogout503ApplicationProblemPlusJSONResponse) VisitPostAuthLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuthMeRequestObject struct {
+}
+
+type GetAuthMeResponseObject interface {
+	VisitGetAuthMeResponse(w http.ResponseWriter) error
+}
+
+type GetAuthMe200JSONResponse User
+
+func (response GetAuthMe200JSONResponse) VisitGetAuthMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthMe401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthMe401ApplicationProblemPlusJSONResponse) VisitGetAuthMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthMe403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthMe403ApplicationProblemPlusJSONResponse) VisitGetAuthMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthMe429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthMe429ApplicationProblemPlusJSONResponse) VisitGetAuthMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuthMe500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthMe500ApplicationProblemPlusJSONResponse) VisitGetAuthMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthMe503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthMe503ApplicationProblemPlusJSONResponse) VisitGetAuthMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthRefreshRequestObject struct {
+	Body *PostAuthRefreshJSONRequestBody
+}
+
+type PostAuthRefreshResponseObject interface {
+	VisitPostAuthRefreshResponse(w http.ResponseWriter) error
+}
+
+type PostAuthRefresh200JSONResponse TokenPair
+
+func (response PostAuthRefresh200JSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefresh400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh400ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefresh401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh401ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefresh403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh403ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefresh413ApplicationProblemPlusJSONResponse struct {
+	PayloadTooLargeApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh413ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefresh429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh429ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthRefresh500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh500ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRefresh503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRefresh503ApplicationProblemPlusJSONResponse) VisitPostAuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthRegisterRequestObject struct {
+	Body *PostAuthRegisterJSONRequestBody
+}
+
+type PostAuthRegisterResponseObject interface {
+	VisitPostAuthRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostAuthRegister201JSONResponse User
+
+func (response PostAuthRegister201JSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister400ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister401ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister403ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister409ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister413ApplicationProblemPlusJSONResponse struct {
+	PayloadTooLargeApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister413ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister429ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthRegister500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister500ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthRegister503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthRegister503ApplicationProblemPlusJSONResponse) VisitPostAuthRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteAuthSessionRequestObject struct {
+}
+
+type DeleteAuthSessionResponseObject interface {
+	VisitDeleteAuthSessionResponse(w http.ResponseWriter) error
+}
+
+type DeleteAuthSession204ResponseHeaders struct {
+	SetCookie string
+}
+
+type DeleteAuthSession204Response struct {
+	Headers DeleteAuthSession204ResponseHeaders
+}
+
+func (response DeleteAuthSession204Response) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteAuthSession401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteAuthSession401ApplicationProblemPlusJSONResponse) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAuthSession403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteAuthSession403ApplicationProblemPlusJSONResponse) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAuthSession429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteAuthSession429ApplicationProblemPlusJSONResponse) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteAuthSession500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteAuthSession500ApplicationProblemPlusJSONResponse) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAuthSession503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteAuthSession503ApplicationProblemPlusJSONResponse) VisitDeleteAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuthSessionRequestObject struct {
+}
+
+type GetAuthSessionResponseObject interface {
+	VisitGetAuthSessionResponse(w http.ResponseWriter) error
+}
+
+type GetAuthSession200JSONResponse Session
+
+func (response GetAuthSession200JSONResponse) VisitGetAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthSession401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthSession401ApplicationProblemPlusJSONResponse) VisitGetAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthSession403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthSession403ApplicationProblemPlusJSONResponse) VisitGetAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthSession429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthSession429ApplicationProblemPlusJSONResponse) VisitGetAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuthSession500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthSession500ApplicationProblemPlusJSONResponse) VisitGetAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthSession503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetAuthSession503ApplicationProblemPlusJSONResponse) VisitGetAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthSessionRequestObject struct {
+	Body *PostAuthSessionJSONRequestBody
+}
+
+type PostAuthSessionResponseObject interface {
+	VisitPostAuthSessionResponse(w http.ResponseWriter) error
+}
+
+type PostAuthSession201ResponseHeaders struct {
+	SetCookie string
+}
+
+type PostAuthSession201JSONResponse struct {
+	Body    Session
+	Headers PostAuthSession201ResponseHeaders
+}
+
+func (response PostAuthSession201JSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Set-Cookie", fmt.Sprint(response.Headers.SetCookie))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthSession400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession400ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthSession401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession401ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthSession403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession403ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthSession413ApplicationProblemPlusJSONResponse struct {
+	PayloadTooLargeApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession413ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthSession429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession429ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAuthSession500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession500ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthSession503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response PostAuthSession503ApplicationProblemPlusJSONResponse) VisitPostAuthSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthReport
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealth401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetHealth401ApplicationProblemPlusJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealth429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetHealth429ApplicationProblemPlusJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealth500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetHealth500ApplicationProblemPlusJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealth503JSONResponse HealthReport
+
+func (response GetHealth503JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthzRequestObject struct {
+}
+
+type GetHealthzResponseObject interface {
+	VisitGetHealthzResponse(w http.ResponseWriter) error
+}
+
+type GetHealthz200JSONResponse HealthStatus
+
+func (response GetHealthz200JSONResponse) VisitGetHealthzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthz401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetHealthz401ApplicationProblemPlusJSONResponse) VisitGetHealthzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthz500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetHealthz500ApplicationProblemPlusJSONResponse) VisitGetHealthzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPingRequestObject struct {
+}
+
+type GetPingResponseObject interface {
+	VisitGetPingResponse(w http.ResponseWriter) error
+}
+
+type GetPing200JSONResponse PingResponse
+
+func (response GetPing200JSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPing400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetPing400ApplicationProblemPlusJSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPing401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetPing401ApplicationProblemPlusJSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPing429ApplicationProblemPlusJSONResponse struct {
+	TooManyRequestsApplicationProblemPlusJSONResponse
+}
+
+func (response GetPing429ApplicationProblemPlusJSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPing500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetPing500ApplicationProblemPlusJSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPing503ApplicationProblemPlusJSONResponse struct {
+	ServiceUnavailableApplicationProblemPlusJSONResponse
+}
+
+func (response GetPing503ApplicationProblemPlusJSONResponse) VisitGetPingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetReadyzRequestObject struct {
+}
+
+type GetReadyzResponseObject interface {
+	VisitGetReadyzResponse(w http.ResponseWriter) error
+}
+
+type GetReadyz200JSONResponse HealthStatus
+
+func (response GetReadyz200JSONResponse) VisitGetReadyzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadyz401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetReadyz401ApplicationProblemPlusJSONResponse) VisitGetReadyzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadyz500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetReadyz500ApplicationProblemPlusJSONResponse) VisitGetReadyzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadyz503JSONResponse HealthStatus
+
+func (response GetReadyz503JSONResponse) VisitGetReadyzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Действующие API ключи текущего пользователя
+	// (GET /api-keys)
+	GetApiKeys(ctx context.Context, request GetApiKeysRequestObject) (GetApiKeysResponseObject, error)
+	// Выпуск API ключа, ключ и секрет подписи возвращаются только в этом ответе
+	// (POST /api-keys)
+	PostApiKeys(ctx context.Context, request PostApiKeysRequestObject) (PostApiKeysResponseObject, error)
+	// Отзыв API ключа
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(ctx context.Context, request DeleteApiKeysIdRequestObject) (DeleteApiKeysIdResponseObject, error)
+	// Вход по email и паролю, выдает access и refresh токены
+	// (POST /auth/login)
+	PostAuthLogin(ctx context.Context, request PostAuthLoginRequestObject) (PostAuthLoginResponseObject, error)
+	// Выход, отзывает refresh токен и все токены, выпущенные из него
+	// (POST /auth/logout)
+	PostAuthLogout(ctx context.Context, request PostAuthLogoutRequestObject) (PostAuthLogoutResponseObject, error)
+	// Текущий пользователь
+	// (GET /auth/me)
+	GetAuthMe(ctx context.Context, request GetAuthMeRequestObject) (GetAuthMeResponseObject, error)
+	// Обмен refresh токена на новую пару, старый refresh токен становится недействительным
+	// (POST /auth/refresh)
+	PostAuthRefresh(ctx context.Context, request PostAuthRefreshRequestObject) (PostAuthRefreshResponseObject, error)
+	// Регистрация пользователя по email и паролю
+	// (POST /auth/register)
+	PostAuthRegister(ctx context.Context, request PostAuthRegisterRequestObject) (PostAuthRegisterResponseObject, error)
+	// Выход, завершает сессию и удаляет cookie
+	// (DELETE /auth/session)
+	DeleteAuthSession(ctx context.Context, request DeleteAuthSessionRequestObject) (DeleteAuthSessionResponseObject, error)
+	// Текущая сессия и ее CSRF токен
+	// (GET /auth/session)
+	GetAuthSession(ctx context.Context, request GetAuthSessionRequestObject) (GetAuthSessionResponseObject, error)
+	// Вход для браузерных клиентов, начинает сессию в cookie
+	// (POST /auth/session)
+	PostAuthSession(ctx context.Context, request PostAuthSessionRequestObject) (PostAuthSessionResponseObject, error)
+	// Подробный отчет о состоянии всех зависимостей
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness проба, процесс запущен и обрабатывает запросы
+	// (GET /healthz)
+	GetHealthz(ctx context.Context, request GetHealthzRequestObject) (GetHealthzResponseObject, error)
+	// Ping команда для проверки сервиса
+	// (GET /ping)
+	GetPing(ctx context.Context, request GetPingRequestObject) (GetPingResponseObject, error)
+	// Readiness проба, критичные зависимости доступны и сервис принимает трафик
+	// (GET /readyz)
+	GetReadyz(ctx context.Context, request GetReadyzRequestObject) (GetReadyzResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		},
 		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -286,22 +2045,376 @@ func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareF
 	}}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetApiKeys operation middleware
+func (sh *strictHandler) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	var request GetApiKeysRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiKeys(ctx, request.(GetApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiKeysResponseObject); ok {
+		if err := validResponse.VisitGetApiKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiKeys operation middleware
+func (sh *strictHandler) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+	var request PostApiKeysRequestObject
+
+	var body PostApiKeysJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiKeys(ctx, request.(PostApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiKeysResponseObject); ok {
+		if err := validResponse.VisitPostApiKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+// DeleteApiKeysId operation middleware
+func (sh *strictHandler) DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id UUID) {
+	var request DeleteApiKeysIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiKeysId(ctx, request.(DeleteApiKeysIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiKeysId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiKeysIdResponseObject); ok {
+		if err := validResponse.VisitDeleteApiKeysIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthLogin operation middleware
+func (sh *strictHandler) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthLoginRequestObject
+
+	var body PostAuthLoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthLogin(ctx, request.(PostAuthLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthLogin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAuthLoginResponseObject); ok {
+		if err := validResponse.VisitPostAuthLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthLogout operation middleware
+func (sh *strictHandler) PostAuthLogout(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthLogoutRequestObject
+
+	var body PostAuthLogoutJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthLogout(ctx, request.(PostAuthLogoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthLogout")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAuthLogoutResponseObject); ok {
+		if err := validResponse.VisitPostAuthLogoutResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAuthMe operation middleware
+func (sh *strictHandler) GetAuthMe(w http.ResponseWriter, r *http.Request) {
+	var request GetAuthMeRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuthMe(ctx, request.(GetAuthMeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuthMe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAuthMeResponseObject); ok {
+		if err := validResponse.VisitGetAuthMeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthRefresh operation middleware
+func (sh *strictHandler) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthRefreshRequestObject
+
+	var body PostAuthRefreshJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthRefresh(ctx, request.(PostAuthRefreshRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthRefresh")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAuthRefreshResponseObject); ok {
+		if err := validResponse.VisitPostAuthRefreshResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthRegister operation middleware
+func (sh *strictHandler) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthRegisterRequestObject
+
+	var body PostAuthRegisterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthRegister(ctx, request.(PostAuthRegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthRegister")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAuthRegisterResponseObject); ok {
+		if err := validResponse.VisitPostAuthRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAuthSession operation middleware
+func (sh *strictHandler) DeleteAuthSession(w http.ResponseWriter, r *http.Request) {
+	var request DeleteAuthSessionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAuthSession(ctx, request.(DeleteAuthSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAuthSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAuthSessionResponseObject); ok {
+		if err := validResponse.VisitDeleteAuthSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAuthSession operation middleware
+func (sh *strictHandler) GetAuthSession(w http.ResponseWriter, r *http.Request) {
+	var request GetAuthSessionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuthSession(ctx, request.(GetAuthSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuthSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAuthSessionResponseObject); ok {
+		if err := validResponse.VisitGetAuthSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAuthSession operation middleware
+func (sh *strictHandler) PostAuthSession(w http.ResponseWriter, r *http.Request) {
+	var request PostAuthSessionRequestObject
+
+	var body PostAuthSessionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthSession(ctx, request.(PostAuthSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAuthSessionResponseObject); ok {
+		if err := validResponse.VisitPostAuthSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		if err := validResponse.VisitGetHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHealthz operation middleware
+func (sh *strictHandler) GetHealthz(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthzRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthz(ctx, request.(GetHealthzRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthz")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthzResponseObject); ok {
+		if err := validResponse.VisitGetHealthzResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // GetPing operation middleware
-func (sh *strictHandler) GetPing(w http.ResponseWriter, r *http.Request, params GetPingParams) {
+func (sh *strictHandler) GetPing(w http.ResponseWriter, r *http.Request) {
 	var request GetPingRequestObject
 
-	request.Params = params
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetPing(ctx, request.(GetPingRequestObject))
 	}
@@ -322,21 +2435,107 @@ func (sh *strictHandler) GetPing(w http.ResponseWriter, r *http.Request, params
 	}
 }
 
+// GetReadyz operation middleware
+func (sh *strictHandler) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	var request GetReadyzRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetReadyz(ctx, request.(GetReadyzRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetReadyz")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReadyzResponseObject); ok {
+		if err := validResponse.VisitGetReadyzResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xUzWrbTBR9FXG/LL6CbClpC612Lf0hu5A0UEhcmMpjR6k1o47GgWAEsdtFCoVsu2m7",
-	"6AvYxqImiZRXuPNG5Y6cOnEFbaEb4/nRveecOecOIJRxIgUXOoVgAAlTLOaaK7t62dhNuWpstmnR5mmo",
-	"okRHUkAA+AlnmGNhRjg373GO5zg2IyzNiYNXWOKF+YjfscSp3c7xwpw5/+/ubj65Ay5EVOGAszZX4IJg",
-	"MYfgRjMX0vCAx4y6rinegQD+85Y4veo09agcZFnmguJpIkXKLepNobkSrLfD1RFXT5WSirZDKTQXmv6y",
-	"JOlFISMq3mFKfAZ/2NJW2150q3qv6PIZc7wyJ5jjDKc4r1TCAsfmzMEpFuadGdnjAgtzRpulOcU5TkhB",
-	"xwwxNyc4tb/jJlCDRW+Cdrs9vZeSCVc6qpjza676OCFJU60i0YVKobf9SPE2BHuLay33+pp8fchDDZkL",
-	"W5Ho3iy/wu2bGeIV5uYUCywxJ7QlljgxHyyfOebOPmxJ0d0HcFewxTxNWbeu6leyi61WUxAvwf0NnevK",
-	"dYSsRYIBdKSKmYYA+v2IDJYwTSaBAF7t+Y2HrNF51HjWGjzIGjeX9/5mub6RrdVgzcjuHWlfJdI9OtuW",
-	"vd6OlurYeczCN1wQoCOu0koPv+k31wm7TLhgSQQB3G36Tb9CfWDF9BIqHgygy62hSWhrZ4oqPOeaHtJ+",
-	"sIzzXr2zl1e8ZQKz1kqmNnz/n2XolsnqIvTFjGwCRg6WDuUAJ1ja4UL2K61JJmRBM6TxswzN3AxxTNLd",
-	"r+DWofhJy6ubEzZv/Thm6hgCGwcHz7HESxxjgTMcOzizs8xmvFwE9fxXEC5o1iXV4QVPNbSyLMt+BAAA",
-	"//8FJJ7BbQUAAA==",
+	"H4sIAAAAAAAC/+xc624bR5Z+lULv/LAyTd2szCRc7A/Zsdfa2LEgyVgPTC3RIktSj8hupruZMaMlIJHj",
+	"OIG81iLYH8Fgc90XoGjRoimReoWqN1qcU9X34kWyrXEm/iOI7O7qOud8536Ku1rBLldsi1qeq2V3tW1q",
+	"FKmD/65S1zVt66Zt75gUvihSt+CYFc+0LS2rie8J32cdvs/3WZd1dXLH8yr3rVJNJ+yEtfjXrMv/yvfY",
+	"gLVZi/X/mbBzvse6hLX5AX/CBuyYdcSHfd5gLdZmp/yQdXiD7/NDwvcJ6+KVDuvBWuyM8H1crscG7EzT",
+	"NbewTcsGbM6rVaiW1VzPMa0trV6v65pD3YptuRSpuWEUV+jnVep68KlgWx618F+jUimZBQOomqk49kaJ",
+	"ln//ZxdI3I0s/zuHbmpZ7Z9mQnbNiKvuzLJ4Srw0ziT2PeydDfge30MqGqzPD9grZA8yY8D3dcIGSN4R",
+	"6wFzBrwBjGGn/BnczZ8Qds4G7JR14MFz1oG1+FPgDX7Z5weEtQl1HNtxp7W6rt20rc2SWbhaUn+QW2vx",
+	"r1iXHxJJXoN1WZsNgj03iBRok3/DOuyMNwFDA5TzgB+yPuvy5wRv3+dNvsf3WQuJum07G2axSK0rpeon",
+	"5HxXioA/YycCzUjEKZDZBwwfsxZ/DrTtsRY7wd1/DaJhXfZKJ7zJevA9KIEv0jZ5nKlQp2yilrmEDaL8",
+	"A2ViXXbCzlDAh/w5/wbWyllR5ICKFNJ6KNUMd3BK2BHrsBNyc3XlNrAelAc21oL1T4UyoirClsJV+HOg",
+	"rMWfAqnwHv6UN9lLNmAv2ADua7FXoLPTOQuEs2R51LGM0ip1vqDOLYDilevZOerYMWuzLupPHwk4JKzN",
+	"+rzJG3i5j9w8jKpcS5C9x9qC/4i2z2zvtl21ilcMtgD0Eld94LOgBre1bNRKtlFcs+27hrNFr3R3vwCc",
+	"2CBmuwBGR0IzAG7kztracv7e4sP8jfuf/Cl/409rt1Zx34ALs0AfWMYXhlkyNkpXu/WfpXy7wFhpQdkL",
+	"voeQ7rC+HiNK8J43gVr2EtQE/RHoRwPlccZaAKhAg/qIO7RhvMnOhXadoEProkqeyYvPpjU96mNXqOfU",
+	"MoubHnXihEp3Zloe3aIO0FTXtTXbvmdYNenH3Cvl4Hd8nz9Fxp2jFWsR1kO73mCtGPPAPOrCXLbRpIMl",
+	"avBnBJjAXrI+mI+nUgQnJMIBVENwDKzPjl+LUQ8so+pt2475Jb1aBf4FqTrizSCIEUhBI96AaIh1WU+Y",
+	"eH7o4ye0yj6UOuwVOkTADzoaaQDqfsiDTFlcXvqU1uC/imNXqOOZItYpONTwaDFvIMWbtlOG/7Si4dGM",
+	"Z5appifDJV2jjyumQ90LPWMWx/HqwYOlT+DOkuF6+ap7wT1ZRpkqYjtdqzh003ycjknZ98JjCTPVY6f8",
+	"OXwUcBS8hXjDvxKDZJOdoL9o8GcasMMoV8BIaY67k7+++bExV5jf+GNxQblRt2BXBOtNj5bdsQgK3L5W",
+	"D1YzHMeo4WLmlgXrhoRv2HaJGpYmgtrPq6YDsH4E/JdMClgS7CVcR4/iYT14n73xZ1rw4IUCRndN10tD",
+	"KaBnIsLESmmikvvGtVRbuYk7FctEwvX4ni4DVR9KZePxXWptedtadm52VtfKphV8HinYBND+5iNIhIVN",
+	"RF1HBLciTuwBqP4LlF4RFKLux+9lbRJEMKDwLf6EYK4jYjT4xs8K+FespemXwVrZeLwknro+j8TLT3Mj",
+	"YVikm0a15GnZTaPk0pTR+5YfsHPeRIMlTL005HvIEXYs4uRzzPfOhUdMuQxy597iTU0fB3mJdimX4RAq",
+	"DrONRsXM79DaOLaFSJY3D5E//wqF1RUZqR6LJAl/gpLv+1lPRNgdEUY/AUgoLYrgft6lBYd6ig38HOXw",
+	"aNbqBMJKgaMB60evIxzT708w3eeZYMYQphep5ZlGyVXoa9kwSzFVFd/oUW2c/3BBwYaK4bp/sZ1iUnXn",
+	"P4qp7kfjKPBfGKynIuK2SUvFIHWJ07AJ15S+qExd19hS+anEHsQS4QOqLdyhRsnbvrlNCzsqt256ZsEo",
+	"qTyDrhWrDkY1+bIb47VpeX9Y0PRUoKRr1CdVEef3QJsTdYlzWckBgPcgQU3ZrwFvYGjXgPB5n53K8PoI",
+	"PCwCHpDK9yBmvJC/dz3Dq441dYJ5q57h0aF2Qyykh7yMM264TFZoxXYUzqgAsprcQ0YFrPL9r01oSKHY",
+	"2HCKxPMp8ds7JAM1gX3WSYmcgJXH/OlrKGPopEi3HKNIiyRDMPFphfFsgIu+tFRddA9P4UHF0nrO2jTM",
+	"UnShFok/J5L5+HNBFYPvR1M8kXt1+B7/2vfLYImPEKHNnKXpGrWqZWCYvQMYkHRouga7iHAtBGHItarC",
+	"yL0xyakEFnHiaXX9KVVx6pCclqvOzl4vRMto+A3NigvxHIN1xMWcppOc9kFOExyUtSeff8FKOkk+Hkhh",
+	"T7GZuI+MBdcFo1wxzC0r61CjiPbZgzqSltX+41ou98F/PjIyX+Y/WP991v9n6ncqw7FsWlsrstqr4ND/",
+	"haBlA2GaBmzAjvg3wR5z2rJtbeU0TU/INWLeE6v+KGqyWBtLLcjO0vtMyHuUH/BzzOyuqsraxfKb2Hjc",
+	"RLcJ/yum3GeiQklWbt8kHy98+EdybVjiO5UiuWAXVfT+ryjoAw9FIRc0i52JcnZPVBBT/gKi2TP0CMD6",
+	"VxCAHGFwi8GiSpZF6slwYRzl8M6+HwSFLkaUM1+y4zDUjmxL9UpROVe9ctKyPGaYLUQ/UitsVFAfAmV6",
+	"gfeC5eqJa7Eq2qThfCRGUbgP0yqYEIflzaKCmu8ES2J1CCzQpCqkotMQE2YkiAchoOVtE6ToBeQqyvqA",
+	"5XqGVVAqD77uWYILOvErSwOxMzAYvsmHajhsCR1DpHqrerMjcscL8yFR2eyyk7TwWuRhRuammaWi6u2h",
+	"L4i/GYqjRLS6wA2BoxqgBe3EI6JIjOaZXknFv79ho6CBWOqI3kFMN0QNvDUW+uKL5PIPVpbUS+jE2LCr",
+	"XnajZFg7kbQC78VthBlstJMXS4ojDJAOYbShxKs+K/RRfnKFbjrU3R5aOXDE9bxn74g20sj0P7GN+MPK",
+	"19tKWf0kiB/aQtKVjBtSNTiXUTTwHXtrPdF+CovPrUhws2WUab5suB51NF2rlIwa/uNWaMEzPNtRBjqy",
+	"8auIdV1nM+Rdyh12JAmtQO6snVafDnmYgV5UZg0WAugMZLgJFjXV8uJPxBKBVkJZJNLrelO1zKpLnXHG",
+	"94FLnRQs8EE9ypvYBlQ4QcqXDVORZxqFAnXdkMlDaTNVMvgWJXAGnH+JrOyzLhFLJlp/7VixPW7AI+bH",
+	"x/zF3yqffL3XDmcDXsn71suH+w1qOFQF6mRBI8rl2GIxBic3ouSHSsBY9o5Cr1o1E+Hto9nMx0ZmczFz",
+	"e333o3om+nHhIh/n5uvKkPiBSxXwulRnwC/fvEb935GWcdS9aD2VJW6/eoOrjClng/ulhapjerVVWDio",
+	"+n1Ka4tVbzuN38XlpUhHAFz+8v3VNTJjVMzMDq2504R9i1EdTihE2gqJnmHYS4jV41qpiqgMauI1u2DV",
+	"bM56mFk1tyzDqzo08ymtkWuiuB+5aUon0ZvWzDJ1PaNcIdei+sUPyAPLfJy4+TPbKtCcdU0UaGA19kom",
+	"1+hDxHxNC50KmZv/iMgOZts34jpspIURdpCRdzHwOIvafiCvy/rSj00R1o1RRv6FbNPH16Dsm1m9szj/",
+	"4R+uRZmkBzmszB8G7FgmqTlLXjiXQSTfJ59XqVNLXPd8riS+t5AB8e9gK6t3FjO4DdFxG0xNyZu0qalp",
+	"rBmA+ZNdSb/zktUeZhYrJsgpVB4BNwD+BlokH3ji021f7f7t39e0ZDV9MWmtBSRnoKE5U7K3TMvPKsRX",
+	"0iL5s1BYFMS3hLvZ9ryKhnpx0YGumDrA2+QS5BoImx8KDYh6/NVbq6tL9z/L37x//9OlW/nPFu/dmpom",
+	"7LvUJEsnpj38IGeFsyyAPHbKXorJpvMwsEBpJwZZEhseG3HgXI2YbOnE2je8QRZmr0cELXYTCtqxSyXX",
+	"s51aXrIhLfA6Zj2bNppLEbpDVFhahcfIDaOwQy2waF9QxxVcn52enZ4D6dgVahkVU8tq16dnp2eFt9hG",
+	"6xWYIviwJdoBYNUxmV8qalntX6m3iFtwtcTc2/zs7IgO+MU635FGoXrIQlizAeuFpqrDXgF1C7Nzw1YP",
+	"tjsTa9vjQ9fHPxSOhcET8x+PfyI5RlHXtQ9nZ8c/p5pxwmcn2KVi/iXqqrTso92YrXi0Xtd3kxr7aL2+",
+	"rmtutVw2nBpw/H/CKhxvBooVdWiiGhuO271gg6F5CMDZ2HLB7fpgWte12JSaDJ7squWJkt06NGlsVwHJ",
+	"ZduNYRKZfcMu1t4YHFXN4no8gPCcKq2nNGLuDW/BbzaqlCJoFLdllxRLhALdE2AuMrZ6hVo0N8ETyUm0",
+	"3572BX1v1ovrXEuPBpSJbngi7oOo6oS1sZzzjRgiTZdMWFuMEmDgGJaMOhfU2L84pke1dSA9cCkzu2ax",
+	"LoKBEvVoWo8/we/l8ksii3GMMvVwMuvRrvCV4KtCT4lRe1wL9Qk1SmQMwOmE0i6MmsLAciHwESpg77hy",
+	"zS6MfyKYPv3tadUPvMFO+AFrJ3TqdbAexM6YEQ93V1Vv+y7e9tYcVjAlMZGjenOhW1hyUjmpH0XvIlqs",
+	"GbD2eyf1TqhT1OHIQX0oAmNRhIiTCS0xKMaf6yLOkBmZX/pTluP4QVSjQEGFOlU3SmZBAjKqPHbVm0h7",
+	"4L63oz6J6v5EGrSgnG8JWJDwHPzgPebfdReSisGEUuhClOg5JPrTmCes64+2RBVBT0Tn/ogKtv/6ImtS",
+	"K8uEvqdMR2buVW/7Hn2bibtsXKQN/38nh9NxMD04sOcfVlMnjM/eJ/aXDYKi9ejJwqJfgiS+O0oiF0Cp",
+	"zN8DkEp1GW/kV4KS47ti5a8qTvpecvswGPd4HzK92yHTD+xIFJ6HNCTln4EooEmx8qbuTynsoQFMPyuv",
+	"i0dZVybtw07viIkhdqZWT2XE5dAt05Wnncapo7zzHUla5t6+2/pRbf3EHN4JO/41VAEmUKngGPdvU3d/",
+	"wsirK5uS4bHyYQexh2ZFF1A7l0bGbEcWxKre9mrQBpog6/g5OFl9GJ9PRksUP+24Sr1M2KZT8VHePBP/",
+	"kYZ6/QoR/GuOyCYo7QZphWKWPHZKHqbi0eT4g1tB3/DCCYM+MkcYCrc3F/74rxh2hloCWI+PaL5SnHKN",
+	"JxKxZu/7tOHNgDRMCcTsRtTAdOGIWSfZK79EeqCPiT6ioPyHCj4mVIXIr2S8YSv+Pot49wqvcgr+COMR",
+	"ODEOoynyMEAPz7gIEyimpAQycNpE4TfaIx1FMjrZxpNDkTLSsEN7/CCc2MZJjOiBKfxCHGRQHFeWLxFZ",
+	"Uer83llweo83NT3tosTZprfpnWIn8VR6+a04taY4bab4MQ5xqiHyox384PJa9PfD9tVw9gd2HPvNoD4g",
+	"mfUi4ElynT9Rcx2PlqR+MIUfJHUOcrxjhOyRX4YMjpcS8RNEsR+NCkq7I14cUTWJ1lHK9uWoou0dectb",
+	"h7s8cDj2V21g9Lp9aQBfHogxqd01v6AWtpzOpehauvyffyWMnx8Kylo7EafSj+T5TBjxCyv48RHBSaVX",
+	"kT9bMEx0cFrwbcotdhpRqUz+BAfiODiYClSiid0XoA/MVPRsK2tdaWHjVxw/1/VU5T0WP4OYxCHCMyzk",
+	"HbNW4OBT557jEgiBuAbcVcMQAujaSBuyIu54Z0zIC9kNbSdmusWQtSjC4IG5v4OZeRv+blK+4C+xRZgT",
+	"OdU5/JB5wjKCrM20abx0qEJSZ95TY/hxkU1iPOv1/x8Ab7CrthBUAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,222 @@
+// Code generated by problemgen. DO NOT EDIT.
+
+package api
+
+// ProblemResponse returns the response object of the operation for an error status,
+// false if the operation doesn't declare an application/problem+json response with this status.
+// retryAfter is only used by responses declaring the Retry-After header.
+func ProblemResponse(operationID string, status int, problem Problem, retryAfter int) (interface{}, bool) {
+	switch operationID {
+	case "DeleteApiKeysId":
+		switch status {
+		case 400:
+			return DeleteApiKeysId400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return DeleteApiKeysId401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return DeleteApiKeysId403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 404:
+			return DeleteApiKeysId404ApplicationProblemPlusJSONResponse{NotFoundApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return DeleteApiKeysId429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return DeleteApiKeysId500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return DeleteApiKeysId503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "DeleteAuthSession":
+		switch status {
+		case 401:
+			return DeleteAuthSession401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return DeleteAuthSession403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return DeleteAuthSession429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return DeleteAuthSession500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return DeleteAuthSession503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "GetApiKeys":
+		switch status {
+		case 401:
+			return GetApiKeys401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return GetApiKeys403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return GetApiKeys429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return GetApiKeys500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return GetApiKeys503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "GetAuthMe":
+		switch status {
+		case 401:
+			return GetAuthMe401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return GetAuthMe403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return GetAuthMe429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return GetAuthMe500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return GetAuthMe503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "GetAuthSession":
+		switch status {
+		case 401:
+			return GetAuthSession401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return GetAuthSession403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return GetAuthSession429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return GetAuthSession500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return GetAuthSession503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "GetHealth":
+		switch status {
+		case 401:
+			return GetHealth401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return GetHealth429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return GetHealth500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		}
+	case "GetHealthz":
+		switch status {
+		case 401:
+			return GetHealthz401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 500:
+			return GetHealthz500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		}
+	case "GetPing":
+		switch status {
+		case 400:
+			return GetPing400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return GetPing401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return GetPing429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return GetPing500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return GetPing503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "GetReadyz":
+		switch status {
+		case 401:
+			return GetReadyz401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 500:
+			return GetReadyz500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		}
+	case "PostApiKeys":
+		switch status {
+		case 400:
+			return PostApiKeys400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return PostApiKeys401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return PostApiKeys403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 413:
+			return PostApiKeys413ApplicationProblemPlusJSONResponse{PayloadTooLargeApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return PostApiKeys429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return PostApiKeys500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return PostApiKeys503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "PostAuthLogin":
+		switch status {
+		case 400:
+			return PostAuthLogin400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return PostAuthLogin401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return PostAuthLogin403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 413:
+			return PostAuthLogin413ApplicationProblemPlusJSONResponse{PayloadTooLargeApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return PostAuthLogin429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return PostAuthLogin500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return PostAuthLogin503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "PostAuthLogout":
+		switch status {
+		case 400:
+			return PostAuthLogout400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return PostAuthLogout401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return PostAuthLogout403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 413:
+			return PostAuthLogout413ApplicationProblemPlusJSONResponse{PayloadTooLargeApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return PostAuthLogout429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return PostAuthLogout500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return PostAuthLogout503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "PostAuthRefresh":
+		switch status {
+		case 400:
+			return PostAuthRefresh400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return PostAuthRefresh401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return PostAuthRefresh403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 413:
+			return PostAuthRefresh413ApplicationProblemPlusJSONResponse{PayloadTooLargeApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return PostAuthRefresh429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return PostAuthRefresh500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return PostAuthRefresh503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "PostAuthRegister":
+		switch status {
+		case 400:
+			return PostAuthRegister400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return PostAuthRegister401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return PostAuthRegister403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 409:
+			return PostAuthRegister409ApplicationProblemPlusJSONResponse{ConflictApplicationProblemPlusJSONResponse(problem)}, true
+		case 413:
+			return PostAuthRegister413ApplicationProblemPlusJSONResponse{PayloadTooLargeApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return PostAuthRegister429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return PostAuthRegister500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return PostAuthRegister503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	case "PostAuthSession":
+		switch status {
+		case 400:
+			return PostAuthSession400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse(problem)}, true
+		case 401:
+			return PostAuthSession401ApplicationProblemPlusJSONResponse{UnauthorizedApplicationProblemPlusJSONResponse(problem)}, true
+		case 403:
+			return PostAuthSession403ApplicationProblemPlusJSONResponse{ForbiddenApplicationProblemPlusJSONResponse(problem)}, true
+		case 413:
+			return PostAuthSession413ApplicationProblemPlusJSONResponse{PayloadTooLargeApplicationProblemPlusJSONResponse(problem)}, true
+		case 429:
+			return PostAuthSession429ApplicationProblemPlusJSONResponse{TooManyRequestsApplicationProblemPlusJSONResponse{Body: problem, Headers: TooManyRequestsResponseHeaders{RetryAfter: retryAfter}}}, true
+		case 500:
+			return PostAuthSession500ApplicationProblemPlusJSONResponse{InternalServerErrorApplicationProblemPlusJSONResponse(problem)}, true
+		case 503:
+			return PostAuthSession503ApplicationProblemPlusJSONResponse{ServiceUnavailableApplicationProblemPlusJSONResponse{Body: problem, Headers: ServiceUnavailableResponseHeaders{RetryAfter: retryAfter}}}, true
+		}
+	}
+
+	return nil, false
+}
@@ -1,21 +1,69 @@
 package init
 
 import (
+	"context"
 	"fmt"
+
 	psql "github.com/siyoga/rollstory/internal/inf/postgres/public"
 	"github.com/siyoga/rollstory/pkg/container"
-	"log"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/logger"
 )
 
 func Provide(di *container.DigContainer) {
-	di.Provide(func() *psql.Connection {
-		conn, err := psql.New()
+	di.Provide(func(ctx context.Context, config postgres.Config, log *logger.Logger) (*psql.Connection, error) {
+		return connect(ctx, config, postgres.UserRoleFullAccess, log)
+	})
+
+	// пулы с ограниченными правами открываются только если от них кто-то зависит
+	di.Provide(func(ctx context.Context, config postgres.Config, log *logger.Logger) (*psql.ReadWriteConnection, error) {
+		conn, err := connect(ctx, config, postgres.UserRoleReadWrite, log)
 		if err != nil {
-			log.Fatal(fmt.Errorf("can't initialize postgres connection: %w", err))
+			return nil, err
 		}
 
-		return conn
+		return &psql.ReadWriteConnection{Connection: conn}, nil
+	})
+
+	di.Provide(func(ctx context.Context, config postgres.Config, log *logger.Logger) (*psql.ReadOnlyConnection, error) {
+		conn, err := connect(ctx, config, postgres.UserRoleReadOnly, log)
+		if err != nil {
+			return nil, err
+		}
+
+		return &psql.ReadOnlyConnection{Connection: conn}, nil
 	})
 
 	di.Bind(new(psql.Connection), new(psql.DB))
+	di.Bind(new(psql.ReadWriteConnection), new(psql.ReadWriteDB))
+	di.Bind(new(psql.ReadOnlyConnection), new(psql.ReadOnlyDB))
+
+	di.Provide(func(conn *psql.Connection) *postgres.TxManager {
+		return postgres.NewTxManager(conn.DB)
+	})
+
+	di.Bind(new(postgres.TxManager), new(psql.TxManager))
+
+	di.Provide(psql.NewUserRepository, psql.NewRefreshTokenRepository, psql.NewAPIKeyRepository, psql.NewSessionRepository)
+
+	// фоновая проверка основного пула, статистику потребляют readiness и метрики
+	di.Provide(func(conn *psql.Connection, config postgres.Config) *postgres.Prober {
+		prober := postgres.NewProber(
+			conn.DB,
+			postgres.WithProbeInterval(config.HealthInterval),
+			postgres.WithProbeTimeout(config.HealthTimeout),
+		)
+		prober.Start()
+
+		return prober
+	})
+}
+
+func connect(ctx context.Context, config postgres.Config, role postgres.UserRole, log *logger.Logger) (*psql.Connection, error) {
+	conn, err := psql.New(ctx, config, role, log)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize postgres connection: %w", err)
+	}
+
+	return conn, nil
 }
@@ -0,0 +1,7 @@
+-- Общая триггерная функция для поддержания колонки updated_at в актуальном состоянии
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,11 @@
+-- Корзины token bucket для ограничения частоты запросов, общие для всех инстансов.
+-- Состояние легко восстанавливается, поэтому таблица не пишется в WAL.
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    key        text PRIMARY KEY,
+    tokens     double precision NOT NULL,
+    allowed    boolean NOT NULL,
+    updated_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX rate_limit_buckets_expires_at_idx ON rate_limit_buckets (expires_at);
@@ -0,0 +1,4 @@
+-- Роль определяет разрешения пользователя по политике доступа (pkg/policy)
+ALTER TABLE users
+    ADD COLUMN role text NOT NULL DEFAULT 'player'
+        CONSTRAINT users_role_check CHECK (role IN ('game_master', 'player', 'spectator'));
@@ -0,0 +1,17 @@
+-- API ключи: prefix хранится открыто для поиска, от ключа - только SHA-256.
+-- Секрет подписи запросов зашифрован AES-GCM ключом AUTH_API_KEY_ENCRYPTION_KEY.
+CREATE TABLE api_keys (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name           text NOT NULL,
+    prefix         text NOT NULL UNIQUE,
+    key_hash       bytea NOT NULL,
+    scopes         text[] NOT NULL DEFAULT '{}',
+    signing_secret bytea,
+    expires_at     timestamptz,
+    last_used_at   timestamptz,
+    created_at     timestamptz NOT NULL DEFAULT now(),
+    revoked_at     timestamptz
+);
+
+CREATE INDEX api_keys_user_id_idx ON api_keys (user_id) WHERE revoked_at IS NULL;
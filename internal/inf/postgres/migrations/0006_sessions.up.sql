@@ -0,0 +1,13 @@
+-- Сессии браузерных клиентов. id - SHA-256 случайного токена из cookie, сам токен не хранится.
+CREATE TABLE sessions (
+    id           text PRIMARY KEY,
+    user_id      uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    csrf_token   text NOT NULL,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    last_seen_at timestamptz NOT NULL DEFAULT now(),
+    expires_at   timestamptz NOT NULL
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
+CREATE INDEX sessions_last_seen_at_idx ON sessions (last_seen_at);
@@ -0,0 +1,10 @@
+-- Nonce подписанных запросов. Хранится, пока запрос с ним проходит проверку времени
+-- (AUTH_SIGNATURE_MAX_SKEW), повторный запрос с тем же nonce отклоняется.
+CREATE TABLE api_key_nonces (
+    api_key_id uuid NOT NULL REFERENCES api_keys (id) ON DELETE CASCADE,
+    nonce      text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (api_key_id, nonce)
+);
+
+CREATE INDEX api_key_nonces_expires_at_idx ON api_key_nonces (api_key_id, expires_at);
@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS contains versioned SQL migrations of the service schema.
+// New migrations are created with `service migrate create <name>`.
+//
+//go:embed *.sql
+var FS embed.FS
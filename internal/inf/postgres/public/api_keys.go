@@ -0,0 +1,184 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+
+	appAPIKey "github.com/siyoga/rollstory/internal/app/apikey"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/policy"
+)
+
+const apiKeyColumns = `k.id, k.user_id, k.name, k.prefix, k.key_hash, k.scopes, k.signing_secret,
+	k.expires_at, k.last_used_at, k.created_at, k.revoked_at`
+
+// lastUsedPrecision - не чаще этого last_used_at обновляется на каждый запрос с ключом
+const lastUsedPrecision = time.Minute
+
+type apiKeyRow struct {
+	ID            string           `db:"id"`
+	UserID        string           `db:"user_id"`
+	Name          string           `db:"name"`
+	Prefix        string           `db:"prefix"`
+	KeyHash       []byte           `db:"key_hash"`
+	Scopes        pgtype.TextArray `db:"scopes"`
+	SigningSecret []byte           `db:"signing_secret"`
+	ExpiresAt     *time.Time       `db:"expires_at"`
+	LastUsedAt    *time.Time       `db:"last_used_at"`
+	CreatedAt     time.Time        `db:"created_at"`
+	RevokedAt     *time.Time       `db:"revoked_at"`
+	OwnerRole     *string          `db:"owner_role"`
+}
+
+func (r apiKeyRow) toModel() (*appAPIKey.APIKey, error) {
+	// пустой массив остается пустым срезом: nil означал бы ключ без ограничений
+	scopes := make([]string, 0, len(r.Scopes.Elements))
+	if err := r.Scopes.AssignTo(&scopes); err != nil {
+		return nil, fmt.Errorf("scan api key scopes: %w", err)
+	}
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	key := &appAPIKey.APIKey{
+		ID:            r.ID,
+		UserID:        r.UserID,
+		Name:          r.Name,
+		Prefix:        r.Prefix,
+		KeyHash:       r.KeyHash,
+		Scopes:        scopes,
+		SigningSecret: r.SigningSecret,
+		ExpiresAt:     r.ExpiresAt,
+		LastUsedAt:    r.LastUsedAt,
+		CreatedAt:     r.CreatedAt,
+		RevokedAt:     r.RevokedAt,
+	}
+	if r.OwnerRole != nil {
+		key.OwnerRole = policy.Role(*r.OwnerRole)
+	}
+
+	return key, nil
+}
+
+// APIKeyRepository - API ключи в таблице api_keys
+type APIKeyRepository struct {
+	db DB
+}
+
+func NewAPIKeyRepository(db DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key appAPIKey.APIKey) (*appAPIKey.APIKey, error) {
+	var scopes pgtype.TextArray
+	if err := scopes.Set(key.Scopes); err != nil {
+		return nil, fmt.Errorf("encode api key scopes: %w", err)
+	}
+
+	row, err := postgres.Get[apiKeyRow](ctx, r.db,
+		`INSERT INTO api_keys AS k (user_id, name, prefix, key_hash, scopes, signing_secret, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+apiKeyColumns+`, NULL::text AS owner_role`,
+		key.UserID, key.Name, key.Prefix, key.KeyHash, scopes, key.SigningSecret, key.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel()
+}
+
+// ListActive возвращает неотозванные ключи пользователя, включая истекшие
+func (r *APIKeyRepository) ListActive(ctx context.Context, userID string) ([]appAPIKey.APIKey, error) {
+	rows, err := postgres.Select[apiKeyRow](ctx, r.db,
+		`SELECT `+apiKeyColumns+`, NULL::text AS owner_role
+		FROM api_keys k
+		WHERE k.user_id = $1 AND k.revoked_at IS NULL
+		ORDER BY k.created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]appAPIKey.APIKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, *key)
+	}
+
+	return keys, nil
+}
+
+// GetByPrefix возвращает ключ вместе с текущей ролью владельца
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*appAPIKey.APIKey, error) {
+	row, err := postgres.Get[apiKeyRow](ctx, r.db,
+		`SELECT `+apiKeyColumns+`, u.role AS owner_role
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.prefix = $1`,
+		prefix,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel()
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID string, id string) error {
+	n, err := postgres.Exec(ctx, r.db,
+		`UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`,
+		id, userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return postgres.ErrNotFound
+	}
+
+	return nil
+}
+
+// UseNonce удаляет просроченные nonce ключа и запоминает новый, отдельная очистка
+// таблицы не нужна: у каждого ключа в ней только nonce из окна допустимого времени
+func (r *APIKeyRepository) UseNonce(ctx context.Context, keyID string, nonce string, now time.Time, expiresAt time.Time) (bool, error) {
+	if _, err := postgres.Exec(ctx, r.db,
+		`DELETE FROM api_key_nonces WHERE api_key_id = $1 AND expires_at <= $2`,
+		keyID, now,
+	); err != nil {
+		return false, err
+	}
+
+	inserted, err := postgres.Exec(ctx, r.db,
+		`INSERT INTO api_key_nonces (api_key_id, nonce, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		keyID, nonce, expiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return inserted == 1, nil
+}
+
+// TouchLastUsed обновляет last_used_at с точностью lastUsedPrecision, чтобы частые
+// запросы с одним ключом не писали в таблицу каждый раз
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	_, err := postgres.Exec(ctx, r.db,
+		`UPDATE api_keys SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - make_interval(secs => $2))`,
+		id, lastUsedPrecision.Seconds(),
+	)
+
+	return err
+}
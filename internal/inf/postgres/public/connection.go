@@ -1,24 +1,54 @@
 package public
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/siyoga/rollstory/pkg/db/postgres"
 )
 
+// Connection - пул соединений под пользователем с полными правами
 type Connection struct {
 	*sqlx.DB
 }
 
-func New() (*Connection, error) {
-	db, err := postgres.Connect()
+// ReadWriteConnection - пул соединений под пользователем без прав на изменение схемы
+type ReadWriteConnection struct {
+	*Connection
+}
+
+// ReadOnlyConnection - пул соединений под пользователем только на чтение,
+// при заданном PG_REPLICA_HOST направляется на реплику
+type ReadOnlyConnection struct {
+	*Connection
+}
+
+// New открывает пул и ждет доступности БД с экспоненциальной задержкой между попытками
+func New(ctx context.Context, config postgres.Config, role postgres.UserRole, logger postgres.Logger) (*Connection, error) {
+	db, err := postgres.ConnectWithBackoff(ctx, config, role, logger)
 	if err != nil {
-		return nil, fmt.Errorf("while connecting to postgres: %w", err)
+		return nil, fmt.Errorf("while connecting to postgres as %s: %w", role, err)
 	}
 
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("can't ping on fresh connection: %w", err)
+	return &Connection{db}, nil
+}
+
+// QueryContext выполняет запрос в транзакции из ctx, если она открыта через postgres.TxManager
+func (c *Connection) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if tx, ok := postgres.TxFromContext(ctx); ok {
+		return tx.QueryContext(ctx, query, args...)
 	}
 
-	return &Connection{db}, nil
+	return c.DB.QueryContext(ctx, query, args...)
+}
+
+// ExecContext выполняет запрос в транзакции из ctx, если она открыта через postgres.TxManager
+func (c *Connection) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx, ok := postgres.TxFromContext(ctx); ok {
+		return tx.ExecContext(ctx, query, args...)
+	}
+
+	return c.DB.ExecContext(ctx, query, args...)
 }
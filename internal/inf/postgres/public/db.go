@@ -3,11 +3,29 @@ package public
 import (
 	"context"
 	"database/sql"
+
+	"github.com/siyoga/rollstory/pkg/db/postgres"
 )
 
-// DB Реализуется через *sqlx.DB
+// DB Реализуется через *Connection, запросы учитывают транзакцию из контекста
 type DB interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	Begin() (*sql.Tx, error)
 }
+
+// ReadOnlyDB Реализуется через *ReadOnlyConnection
+type ReadOnlyDB interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ReadWriteDB Реализуется через *ReadWriteConnection
+type ReadWriteDB interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// TxManager Реализуется через *postgres.TxManager
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error, opts ...postgres.TxOption) error
+}
@@ -0,0 +1,105 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	appAuth "github.com/siyoga/rollstory/internal/app/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+)
+
+const refreshTokenColumns = `id, user_id, family_id, token_hash, expires_at, created_at, revoked_at, replaced_by`
+
+type refreshTokenRow struct {
+	ID         string     `db:"id"`
+	UserID     string     `db:"user_id"`
+	FamilyID   string     `db:"family_id"`
+	Hash       []byte     `db:"token_hash"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	ReplacedBy *string    `db:"replaced_by"`
+}
+
+func (r refreshTokenRow) toModel() *appAuth.RefreshToken {
+	return &appAuth.RefreshToken{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		FamilyID:   r.FamilyID,
+		Hash:       r.Hash,
+		ExpiresAt:  r.ExpiresAt,
+		CreatedAt:  r.CreatedAt,
+		RevokedAt:  r.RevokedAt,
+		ReplacedBy: r.ReplacedBy,
+	}
+}
+
+// RefreshTokenRepository - хеши refresh токенов в таблице refresh_tokens
+type RefreshTokenRepository struct {
+	db DB
+}
+
+func NewRefreshTokenRepository(db DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token appAuth.RefreshToken) (*appAuth.RefreshToken, error) {
+	row, err := postgres.Get[refreshTokenRow](ctx, r.db,
+		`INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+refreshTokenColumns,
+		token.UserID, token.FamilyID, token.Hash, token.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, hash []byte) (*appAuth.RefreshToken, error) {
+	row, err := postgres.Get[refreshTokenRow](ctx, r.db,
+		`SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+// GetByHashForUpdate блокирует строку токена до конца транзакции из ctx,
+// чтобы параллельные обновления одним токеном не выпустили две пары
+func (r *RefreshTokenRepository) GetByHashForUpdate(ctx context.Context, hash []byte) (*appAuth.RefreshToken, error) {
+	row, err := postgres.Get[refreshTokenRow](ctx, r.db,
+		`SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE`, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+// Replace отзывает токен и ссылается на выпущенный вместо него
+func (r *RefreshTokenRepository) Replace(ctx context.Context, id string, replacedBy string) error {
+	n, err := postgres.Exec(ctx, r.db,
+		`UPDATE refresh_tokens SET revoked_at = now(), replaced_by = $2 WHERE id = $1 AND revoked_at IS NULL`,
+		id, replacedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return postgres.ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeFamily отзывает все действующие токены цепочки
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := postgres.Exec(ctx, r.db,
+		`UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL`, familyID)
+
+	return err
+}
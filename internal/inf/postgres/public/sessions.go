@@ -0,0 +1,85 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+type sessionRow struct {
+	ID         string    `db:"id"`
+	UserID     string    `db:"user_id"`
+	CSRFToken  string    `db:"csrf_token"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastSeenAt time.Time `db:"last_seen_at"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	Role       string    `db:"role"`
+}
+
+func (r sessionRow) toModel() *session.Session {
+	return &session.Session{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Roles:      []string{r.Role},
+		CSRFToken:  r.CSRFToken,
+		CreatedAt:  r.CreatedAt,
+		LastSeenAt: r.LastSeenAt,
+		ExpiresAt:  r.ExpiresAt,
+	}
+}
+
+// SessionRepository - сессии браузерных клиентов в таблице sessions
+type SessionRepository struct {
+	db DB
+}
+
+func NewSessionRepository(db DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, s session.Session) error {
+	_, err := postgres.Exec(ctx, r.db,
+		`INSERT INTO sessions (id, user_id, csrf_token, created_at, last_seen_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		s.ID, s.UserID, s.CSRFToken, s.CreatedAt, s.LastSeenAt, s.ExpiresAt,
+	)
+
+	return err
+}
+
+// Get возвращает сессию вместе с текущей ролью владельца, смена роли действует сразу
+func (r *SessionRepository) Get(ctx context.Context, id string) (*session.Session, error) {
+	row, err := postgres.Get[sessionRow](ctx, r.db,
+		`SELECT s.id, s.user_id, s.csrf_token, s.created_at, s.last_seen_at, s.expires_at, u.role
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.id = $1`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, id string, seenAt time.Time) error {
+	_, err := postgres.Exec(ctx, r.db, `UPDATE sessions SET last_seen_at = $2 WHERE id = $1`, id, seenAt)
+	return err
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	_, err := postgres.Exec(ctx, r.db, `DELETE FROM sessions WHERE id = $1`, id)
+	return err
+}
+
+func (r *SessionRepository) DeleteExpired(ctx context.Context, now time.Time, idleTimeout time.Duration) (int64, error) {
+	return postgres.Exec(ctx, r.db,
+		`DELETE FROM sessions
+		WHERE expires_at <= $1
+			OR ($2::float8 > 0 AND last_seen_at < $1 - make_interval(secs => $2::float8))`,
+		now, idleTimeout.Seconds(),
+	)
+}
@@ -0,0 +1,86 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	appAuth "github.com/siyoga/rollstory/internal/app/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/policy"
+)
+
+const userColumns = `id, email, password_hash, role, created_at, updated_at`
+
+type userRow struct {
+	ID           string    `db:"id"`
+	Email        string    `db:"email"`
+	PasswordHash string    `db:"password_hash"`
+	Role         string    `db:"role"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+func (r userRow) toModel() *appAuth.User {
+	return &appAuth.User{
+		ID:           r.ID,
+		Email:        r.Email,
+		PasswordHash: r.PasswordHash,
+		Role:         policy.Role(r.Role),
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+// UserRepository - учетные записи пользователей в таблице users
+type UserRepository struct {
+	db DB
+}
+
+func NewUserRepository(db DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create добавляет пользователя с ролью по умолчанию, занятый email возвращает postgres.ErrUniqueViolation
+func (r *UserRepository) Create(ctx context.Context, email string, passwordHash string) (*appAuth.User, error) {
+	row, err := postgres.Get[userRow](ctx, r.db,
+		`INSERT INTO users (email, password_hash) VALUES ($1, $2) RETURNING `+userColumns,
+		email, passwordHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*appAuth.User, error) {
+	row, err := postgres.Get[userRow](ctx, r.db, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+// GetByEmail ищет без учета регистра по индексу users_email_key
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*appAuth.User, error) {
+	row, err := postgres.Get[userRow](ctx, r.db, `SELECT `+userColumns+` FROM users WHERE lower(email) = lower($1)`, email)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id string, passwordHash string) error {
+	n, err := postgres.Exec(ctx, r.db, `UPDATE users SET password_hash = $2 WHERE id = $1`, id, passwordHash)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return postgres.ErrNotFound
+	}
+
+	return nil
+}
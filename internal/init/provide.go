@@ -1,14 +1,29 @@
 package init
 
-import "github.com/siyoga/rollstory/pkg/container"
+import (
+	"context"
 
-func NewContainer() *container.DigContainer {
+	"github.com/siyoga/rollstory/internal/config"
+	"github.com/siyoga/rollstory/pkg/container"
+	"github.com/siyoga/rollstory/pkg/logger"
+)
+
+// NewContainer registers all components. ctx bounds blocking work done by constructors,
+// e.g. waiting for the database on startup. log is shared with the caller.
+func NewContainer(ctx context.Context, cfg *config.Config, log *logger.Logger) *container.DigContainer {
 	di := container.New()
 
-	provideLogger(di)
+	provideConfig(di, cfg)
+	di.Provide(func() context.Context { return ctx })
+
+	provideLogger(di, log)
 
 	provideInf(di)
 
+	provideHealth(di)
+
+	provideHTTP(di)
+
 	// Register app layer (service layer)
 	provideApp(di)
 
@@ -1,8 +1,15 @@
 package init
 
 import (
+	"github.com/siyoga/rollstory/internal/app/apikey"
+	"github.com/siyoga/rollstory/internal/app/auth"
+	"github.com/siyoga/rollstory/internal/app/health"
 	"github.com/siyoga/rollstory/internal/app/ping"
+	psql "github.com/siyoga/rollstory/internal/inf/postgres/public"
+	pkgAuth "github.com/siyoga/rollstory/pkg/auth"
 	"github.com/siyoga/rollstory/pkg/container"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/session"
 )
 
 // provideApp registers all app layer (service layer) handlers
@@ -10,8 +17,39 @@ func provideApp(c *container.DigContainer) {
 	// Register ping service handler
 	c.Provide(ping.NewHandler)
 
+	// Register health service handler
+	c.Provide(health.NewHandler)
+
+	// Register auth service handler with its repositories and token primitives
+	c.Provide(
+		func(cfg pkgAuth.Config) *pkgAuth.PasswordHasher {
+			return pkgAuth.NewPasswordHasher(cfg.Argon2Params())
+		},
+		func(cfg pkgAuth.Config) (*pkgAuth.TokenIssuer, error) {
+			return pkgAuth.NewTokenIssuer(
+				cfg.JWTSecret,
+				pkgAuth.WithIssuer(cfg.JWTIssuer),
+				pkgAuth.WithAccessTTL(cfg.AccessTokenTTL),
+				pkgAuth.WithPreviousSecrets(cfg.JWTPreviousSecrets...),
+			)
+		},
+	)
+	c.Provide(auth.NewHandler).
+		Bind(new(psql.UserRepository), new(auth.UserRepository)).
+		Bind(new(psql.RefreshTokenRepository), new(auth.RefreshTokenRepository)).
+		Bind(new(pkgAuth.PasswordHasher), new(auth.PasswordHasher)).
+		Bind(new(pkgAuth.TokenIssuer), new(auth.TokenIssuer)).
+		Bind(new(postgres.TxManager), new(auth.TxManager)).
+		Bind(new(session.Manager), new(auth.SessionManager))
+
+	// Register API key service handler, it also verifies keys for the authentication middleware
+	c.Provide(apikey.NewHandler).
+		Bind(new(psql.APIKeyRepository), new(apikey.Repository))
+
+	// Register the access policy shared by the authorization middleware and handlers
+	c.Provide(auth.NewPolicy)
+
 	// Future handlers will be registered here:
 	// c.Provide(user.NewHandler)
-	// c.Provide(auth.NewHandler)
 	// etc.
 }
@@ -0,0 +1,25 @@
+package init
+
+import (
+	"github.com/siyoga/rollstory/internal/config"
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/container"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/health"
+	"github.com/siyoga/rollstory/pkg/http/listener"
+	"github.com/siyoga/rollstory/pkg/http/router/middleware"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// provideConfig registers loaded configuration and its per-component parts
+func provideConfig(di *container.DigContainer, cfg *config.Config) {
+	di.Provide(
+		func() *config.Config { return cfg },
+		func(c *config.Config) auth.Config { return c.Auth },
+		func(c *config.Config) health.Config { return c.Health },
+		func(c *config.Config) listener.Config { return c.Listener },
+		func(c *config.Config) middleware.Config { return c.HTTP },
+		func(c *config.Config) postgres.Config { return c.Postgres },
+		func(c *config.Config) session.Config { return c.Session },
+	)
+}
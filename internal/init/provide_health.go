@@ -0,0 +1,23 @@
+package init
+
+import (
+	"github.com/siyoga/rollstory/pkg/container"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+	"github.com/siyoga/rollstory/pkg/health"
+)
+
+// provideHealth registers the health check registry with checks of every dependency
+func provideHealth(di *container.DigContainer) {
+	di.Provide(func(cfg health.Config, prober *postgres.Prober) *health.Registry {
+		registry := health.NewRegistry(health.WithDefaultTimeout(cfg.CheckTimeout))
+
+		registry.Register("postgres", prober.Check)
+		registry.Register(
+			"disk",
+			health.DiskSpace(cfg.DiskPath, cfg.DiskMinFree),
+			health.WithCritical(false),
+		)
+
+		return registry
+	})
+}
@@ -0,0 +1,36 @@
+package init
+
+import (
+	"github.com/siyoga/rollstory/internal/config"
+	psql "github.com/siyoga/rollstory/internal/inf/postgres/public"
+	"github.com/siyoga/rollstory/pkg/container"
+	"github.com/siyoga/rollstory/pkg/logger"
+	"github.com/siyoga/rollstory/pkg/ratelimit"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// provideHTTP registers shared state of HTTP middlewares
+func provideHTTP(di *container.DigContainer) {
+	// the postgres store shares limits between instances, its cleanup is stopped on shutdown
+	di.Provide(func(cfg *config.Config, conn *psql.Connection, log *logger.Logger) ratelimit.Store {
+		if cfg.HTTP.RateLimitStore != "postgres" {
+			return ratelimit.NewMemoryStore()
+		}
+
+		store := ratelimit.NewPostgresStore(conn, ratelimit.WithPostgresLogger(log))
+		store.Start()
+
+		return store
+	})
+
+	// sessions are loaded by the authentication middleware, their cleanup is stopped on shutdown
+	di.Provide(func(cfg session.Config, repo *psql.SessionRepository, log *logger.Logger) (*session.Manager, error) {
+		manager, err := session.NewManager(repo, cfg, session.WithLogger(log))
+		if err != nil {
+			return nil, err
+		}
+		manager.Start()
+
+		return manager, nil
+	})
+}
@@ -3,18 +3,10 @@ package init
 import (
 	"github.com/siyoga/rollstory/pkg/container"
 	"github.com/siyoga/rollstory/pkg/logger"
-	"log"
 )
 
-func provideLogger(di *container.DigContainer) {
-	di.Provide(
-		func() *logger.Logger {
-			customLogger, err := logger.New()
-			if err != nil {
-				log.Fatalf("инициализация логгера: %w", err)
-			}
-
-			return customLogger
-		},
-	)
+// provideLogger registers the logger created by main, so the container and main write
+// through the same instance and admin log level changes apply to both
+func provideLogger(di *container.DigContainer, log *logger.Logger) {
+	di.Provide(func() *logger.Logger { return log })
 }
@@ -2,7 +2,13 @@ package init
 
 import (
 	"github.com/siyoga/rollstory/internal/api"
+	rpcAPIKey "github.com/siyoga/rollstory/internal/api/apikey"
+	rpcAuth "github.com/siyoga/rollstory/internal/api/auth"
+	rpcHealth "github.com/siyoga/rollstory/internal/api/health"
 	rpcPing "github.com/siyoga/rollstory/internal/api/ping"
+	appAPIKey "github.com/siyoga/rollstory/internal/app/apikey"
+	appAuth "github.com/siyoga/rollstory/internal/app/auth"
+	appHealth "github.com/siyoga/rollstory/internal/app/health"
 	appPing "github.com/siyoga/rollstory/internal/app/ping"
 	"github.com/siyoga/rollstory/pkg/container"
 )
@@ -19,6 +25,23 @@ func provideRPC(c *container.DigContainer) {
 		// Bind error handler to RPC layer interface
 		Bind(new(api.ErrorHandler), new(rpcPing.ErrorHandler))
 
+	// Register health RPC handler
+	c.Provide(rpcHealth.NewHandler).
+		Bind(new(appHealth.Handler), new(rpcHealth.HealthHandler))
+
+	// Register auth RPC handler
+	c.Provide(rpcAuth.NewHandler).
+		Bind(new(appAuth.Handler), new(rpcAuth.AuthHandler)).
+		Bind(new(api.ErrorHandler), new(rpcAuth.ErrorHandler))
+
+	// Register API key RPC handler
+	c.Provide(rpcAPIKey.NewHandler).
+		Bind(new(appAPIKey.Handler), new(rpcAPIKey.APIKeyHandler)).
+		Bind(new(api.ErrorHandler), new(rpcAPIKey.ErrorHandler))
+
+	// Combine all RPC handlers into the strict server implementation
+	c.Provide(api.NewServer)
+
 	// Future RPC handlers will be registered here with their bindings:
 	// c.Provide(rpcUser.NewHandler).
 	//   Bind(new(appUser.Handler), new(rpcUser.UserHandler)).
@@ -0,0 +1,178 @@
+// Package apperror описывает типизированные ошибки предметной области.
+// Ошибка несет вид (Kind), машиночитаемый код и сообщение, которое безопасно показывать клиенту,
+// а внутренняя причина остается только в логах.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindRateLimited
+	KindUnavailable
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindUnauthorized:
+		return "unauthorized"
+	case KindForbidden:
+		return "forbidden"
+	case KindRateLimited:
+		return "rate_limited"
+	case KindUnavailable:
+		return "unavailable"
+	default:
+		return "internal"
+	}
+}
+
+// Error - ошибка предметной области
+type Error struct {
+	Kind Kind
+	// Code - машиночитаемый код ошибки, например game_not_found
+	Code string
+	// Message - сообщение для клиента, не должно содержать внутренних деталей
+	Message string
+	// RetryAfter - через сколько клиенту стоит повторить запрос, для RateLimited и Unavailable
+	RetryAfter time.Duration
+
+	fieldErrors []problem.FieldError
+	fields      map[string]any
+	cause       error
+}
+
+func newError(kind Kind, code string, message string, opts []Option) *Error {
+	e := &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// NotFound - запрошенный объект не существует или недоступен пользователю
+func NotFound(code string, message string, opts ...Option) *Error {
+	return newError(KindNotFound, code, message, opts)
+}
+
+// Conflict - операция противоречит текущему состоянию объекта
+func Conflict(code string, message string, opts ...Option) *Error {
+	return newError(KindConflict, code, message, opts)
+}
+
+// Validation - входные данные некорректны, ошибки полей задаются через WithFieldError
+func Validation(code string, message string, opts ...Option) *Error {
+	return newError(KindValidation, code, message, opts)
+}
+
+// Unauthorized - пользователь не аутентифицирован
+func Unauthorized(code string, message string, opts ...Option) *Error {
+	return newError(KindUnauthorized, code, message, opts)
+}
+
+// Forbidden - у пользователя нет прав на операцию
+func Forbidden(code string, message string, opts ...Option) *Error {
+	return newError(KindForbidden, code, message, opts)
+}
+
+// RateLimited - превышен лимит запросов
+func RateLimited(code string, message string, opts ...Option) *Error {
+	return newError(KindRateLimited, code, message, opts)
+}
+
+// Unavailable - зависимость временно недоступна, запрос можно повторить
+func Unavailable(code string, message string, opts ...Option) *Error {
+	return newError(KindUnavailable, code, message, opts)
+}
+
+// Internal оборачивает непредвиденную ошибку, клиент получает только общее сообщение
+func Internal(cause error, opts ...Option) *Error {
+	return newError(KindInternal, "internal", "internal error", append([]Option{WithCause(cause)}, opts...))
+}
+
+// From возвращает *Error из цепочки err или оборачивает err в Internal
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return Internal(err)
+}
+
+// IsKind проверяет, что в цепочке err есть *Error указанного вида
+func IsKind(err error, kind Kind) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Kind == kind
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Kind, e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.cause != nil {
+		msg += ": " + e.cause.Error()
+	}
+
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// FieldErrors возвращает ошибки отдельных полей, см. problem.FieldErrorer
+func (e *Error) FieldErrors() []problem.FieldError {
+	return e.fieldErrors
+}
+
+// LoggerFields добавляет вид, код ошибки и поля причины в лог, см. logger.WithError
+func (e *Error) LoggerFields() map[string]any {
+	fields := map[string]any{
+		"error_kind": e.Kind.String(),
+		"error_code": e.Code,
+	}
+
+	// логгер смотрит LoggerFields только у самой ошибки, поэтому поля причины собираем здесь
+	var withFields interface{ LoggerFields() map[string]any }
+	if e.cause != nil && errors.As(e.cause, &withFields) {
+		for k, v := range withFields.LoggerFields() {
+			fields[k] = v
+		}
+	}
+
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+
+	return fields
+}
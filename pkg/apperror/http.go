@@ -0,0 +1,38 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+// HTTPStatus возвращает HTTP статус ответа для вида ошибки
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Problem преобразует ошибку в тело ответа application/problem+json
+func (e *Error) Problem() *problem.Problem {
+	p := problem.New(e.Kind.HTTPStatus(), e.Message)
+	p.Code = e.Code
+	p.Errors = e.fieldErrors
+
+	return p
+}
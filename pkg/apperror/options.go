@@ -0,0 +1,40 @@
+package apperror
+
+import (
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+type Option func(*Error)
+
+// WithCause сохраняет внутреннюю причину ошибки, она попадает в лог, но не в ответ клиенту
+func WithCause(err error) Option {
+	return func(e *Error) {
+		e.cause = err
+	}
+}
+
+// WithField добавляет поле в лог
+func WithField(key string, value any) Option {
+	return func(e *Error) {
+		if e.fields == nil {
+			e.fields = make(map[string]any)
+		}
+		e.fields[key] = value
+	}
+}
+
+// WithFieldError добавляет ошибку конкретного поля запроса, она возвращается клиенту
+func WithFieldError(field string, message string) Option {
+	return func(e *Error) {
+		e.fieldErrors = append(e.fieldErrors, problem.FieldError{Field: field, Message: message})
+	}
+}
+
+// WithRetryAfter подсказывает клиенту, когда повторить запрос
+func WithRetryAfter(d time.Duration) Option {
+	return func(e *Error) {
+		e.RetryAfter = d
+	}
+}
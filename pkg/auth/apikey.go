@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix отличает API ключи от других секретов, в том числе при поиске утечек в коде
+const APIKeyPrefix = "rsk"
+
+const (
+	apiKeyLookupBytes = 6
+	apiKeySecretBytes = 32
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrExpiredAPIKey = errors.New("api key expired")
+)
+
+// APIKeyPrincipal - владелец API ключа и ограничения, с которыми ключ действует от его имени
+type APIKeyPrincipal struct {
+	KeyID  string
+	UserID string
+	Roles  []string
+	Scopes []string
+}
+
+// NewAPIKey генерирует ключ вида rsk_<id>_<secret>. Клиенту отдается key, prefix (rsk_<id>)
+// хранится открыто и нужен для поиска и отображения ключа, от секрета хранится только hash.
+func NewAPIKey() (key string, prefix string, hash []byte, err error) {
+	lookup := make([]byte, apiKeyLookupBytes)
+	if _, err := rand.Read(lookup); err != nil {
+		return "", "", nil, fmt.Errorf("generate api key id: %w", err)
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", nil, fmt.Errorf("generate api key: %w", err)
+	}
+
+	prefix = APIKeyPrefix + "_" + hex.EncodeToString(lookup)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// ParseAPIKeyPrefix возвращает prefix ключа, по которому он ищется в базе
+func ParseAPIKeyPrefix(key string) (string, bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != APIKeyPrefix || len(parts[1]) != 2*apiKeyLookupBytes || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[0] + "_" + parts[1], true
+}
+
+// HashAPIKey возвращает хеш ключа для хранения, см. HashRefreshToken
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
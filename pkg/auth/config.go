@@ -0,0 +1,36 @@
+package auth
+
+import "time"
+
+// Config описывает настройки аутентификации, заполняется через env.Load
+type Config struct {
+	// JWTSecret - ключ подписи access токенов HS256, не короче 32 байт
+	JWTSecret string `env:"AUTH_JWT_SECRET" required:"true"`
+	// JWTPreviousSecrets - прежние ключи, токены с ними принимаются до истечения срока, нужны для ротации
+	JWTPreviousSecrets []string      `env:"AUTH_JWT_PREVIOUS_SECRETS"`
+	JWTIssuer          string        `env:"AUTH_JWT_ISSUER" default:"rollstory"`
+	AccessTokenTTL     time.Duration `env:"AUTH_ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL    time.Duration `env:"AUTH_REFRESH_TOKEN_TTL" default:"720h"`
+
+	// APIKeyEncryptionKey шифрует секреты подписи запросов API ключами (32 байта в base64),
+	// без него ключи с подписью не выпускаются
+	APIKeyEncryptionKey SecretKey `env:"AUTH_API_KEY_ENCRYPTION_KEY"`
+	// SignatureMaxSkew - допустимое расхождение времени подписи запроса с часами сервера
+	SignatureMaxSkew time.Duration `env:"AUTH_SIGNATURE_MAX_SKEW" default:"5m"`
+
+	// Параметры Argon2id, при их изменении хеши пересчитываются при следующем входе
+	Argon2MemoryKB    uint32 `env:"AUTH_ARGON2_MEMORY_KB" default:"65536"`
+	Argon2Iterations  uint32 `env:"AUTH_ARGON2_ITERATIONS" default:"3"`
+	Argon2Parallelism uint8  `env:"AUTH_ARGON2_PARALLELISM" default:"2"`
+}
+
+// Argon2Params собирает параметры хеширования паролей из Config
+func (c Config) Argon2Params() Argon2Params {
+	return Argon2Params{
+		Memory:      c.Argon2MemoryKB,
+		Iterations:  c.Argon2Iterations,
+		Parallelism: c.Argon2Parallelism,
+		SaltLength:  defaultSaltLength,
+		KeyLength:   defaultKeyLength,
+	}
+}
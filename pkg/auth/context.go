@@ -0,0 +1,55 @@
+package auth
+
+import "context"
+
+type userIDKey struct{}
+
+// WithUserID сохраняет идентификатор аутентифицированного пользователя в контексте
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext возвращает идентификатор аутентифицированного пользователя
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+type rolesKey struct{}
+
+// WithRoles сохраняет роли аутентифицированного пользователя в контексте
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// RolesFromContext возвращает роли аутентифицированного пользователя
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+type scopesKey struct{}
+
+// WithScopes ограничивает разрешения запроса областями API ключа
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFromContext возвращает области API ключа, ok = false для запросов без API ключа
+func ScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).([]string)
+	return scopes, ok
+}
+
+type apiKeyIDKey struct{}
+
+// WithAPIKeyID отмечает, что запрос аутентифицирован API ключом
+func WithAPIKeyID(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, apiKeyIDKey{}, keyID)
+}
+
+// APIKeyIDFromContext возвращает идентификатор API ключа, которым аутентифицирован запрос
+func APIKeyIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(apiKeyIDKey{}).(string)
+	return id, ok && id != ""
+}
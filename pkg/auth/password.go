@@ -0,0 +1,134 @@
+// Package auth содержит примитивы аутентификации: хеширование паролей Argon2id,
+// access токены JWT (HS256), refresh токены, API ключи с подписью запросов HMAC
+// и передачу пользователя через контекст
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	defaultSaltLength = 16
+	defaultKeyLength  = 32
+)
+
+// ErrInvalidHash - строка не является хешем Argon2id в формате PHC
+var ErrInvalidHash = errors.New("invalid argon2id hash")
+
+// Argon2Params - параметры Argon2id, Memory в килобайтах
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params соответствуют рекомендациям OWASP для Argon2id
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  defaultSaltLength,
+	KeyLength:   defaultKeyLength,
+}
+
+// PasswordHasher хеширует и проверяет пароли. Хеш хранится в формате PHC:
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>, поэтому параметры можно менять без миграции.
+type PasswordHasher struct {
+	params Argon2Params
+}
+
+func NewPasswordHasher(params Argon2Params) *PasswordHasher {
+	if params.SaltLength == 0 {
+		params.SaltLength = defaultSaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = defaultKeyLength
+	}
+
+	return &PasswordHasher{params: params}
+}
+
+// Hash возвращает хеш пароля со случайной солью
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify сравнивает пароль с хешем за постоянное время, параметры берутся из хеша
+func (h *PasswordHasher) Verify(password string, encoded string) (bool, error) {
+	params, salt, key, err := decodeHash(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash сообщает, что хеш создан с другими параметрами и его стоит пересчитать
+func (h *PasswordHasher) NeedsRehash(encoded string) bool {
+	params, _, _, err := decodeHash(encoded)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		params.KeyLength != h.params.KeyLength
+}
+
+func decodeHash(encoded string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
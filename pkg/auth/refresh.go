@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+const refreshTokenBytes = 32
+
+// NewRefreshToken генерирует случайный refresh токен. Клиенту отдается token,
+// в базе хранится только hash, поэтому утечка таблицы не дает действующих токенов.
+func NewRefreshToken() (token string, hash []byte, err error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken возвращает хеш токена для поиска в базе. Токен случайный и длинный,
+// поэтому медленный хеш с солью не нужен.
+func HashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const sealKeyLength = 32
+
+var ErrUnsealFailed = errors.New("unseal: message is corrupted or sealed with another key")
+
+// SecretKey - ключ AES-256, в конфигурации задается в base64
+type SecretKey []byte
+
+// UnmarshalText позволяет проверить длину ключа при загрузке конфигурации
+func (k *SecretKey) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*k = nil
+		return nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("secret key must be base64: %w", err)
+	}
+
+	if len(key) != sealKeyLength {
+		return fmt.Errorf("secret key must be %d bytes, got %d", sealKeyLength, len(key))
+	}
+
+	*k = key
+
+	return nil
+}
+
+// Sealer шифрует небольшие секреты AES-256-GCM: шифртекст нельзя ни прочитать, ни незаметно изменить
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+func NewSealer(key SecretKey) (*Sealer, error) {
+	if len(key) != sealKeyLength {
+		return nil, fmt.Errorf("seal key must be %d bytes", sealKeyLength)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("init cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("init gcm: %w", err)
+	}
+
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal шифрует plaintext со случайным nonce. associated не шифруется, но привязывается
+// к шифртексту: Open с другим associated не пройдет, поэтому секрет нельзя перенести в чужую запись.
+func (s *Sealer) Seal(plaintext []byte, associated []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plaintext)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	return s.aead.Seal(nonce, nonce, plaintext, associated), nil
+}
+
+// Open расшифровывает результат Seal
+func (s *Sealer) Open(sealed []byte, associated []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, ErrUnsealFailed
+	}
+
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, associated)
+	if err != nil {
+		return nil, ErrUnsealFailed
+	}
+
+	return plaintext, nil
+}
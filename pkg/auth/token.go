@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	minSecretLength    = 32
+	defaultAccessTTL   = 15 * time.Minute
+	defaultTokenLeeway = 30 * time.Second
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// Claims - поля access токена
+type Claims struct {
+	ID        string   `json:"jti"`
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+// AccessToken - подписанный токен и момент его истечения
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// TokenIssuer выпускает и проверяет access токены JWT с подписью HS256
+type TokenIssuer struct {
+	secret   []byte
+	previous [][]byte
+	issuer   string
+	ttl      time.Duration
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+type TokenOption func(*TokenIssuer)
+
+// WithIssuer задает iss токенов, токены другого издателя отклоняются
+func WithIssuer(issuer string) TokenOption {
+	return func(t *TokenIssuer) {
+		t.issuer = issuer
+	}
+}
+
+// WithAccessTTL задает время жизни access токена
+func WithAccessTTL(ttl time.Duration) TokenOption {
+	return func(t *TokenIssuer) {
+		if ttl > 0 {
+			t.ttl = ttl
+		}
+	}
+}
+
+// WithPreviousSecrets задает прежние ключи подписи: ими токены только проверяются
+func WithPreviousSecrets(secrets ...string) TokenOption {
+	return func(t *TokenIssuer) {
+		for _, s := range secrets {
+			if s != "" {
+				t.previous = append(t.previous, []byte(s))
+			}
+		}
+	}
+}
+
+func NewTokenIssuer(secret string, opts ...TokenOption) (*TokenIssuer, error) {
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("jwt secret must be at least %d bytes", minSecretLength)
+	}
+
+	t := &TokenIssuer{
+		secret: []byte(secret),
+		ttl:    defaultAccessTTL,
+		leeway: defaultTokenLeeway,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
+}
+
+// Issue выпускает access токен для пользователя subject. Роли попадают в токен,
+// поэтому их изменение вступает в силу после обновления токена.
+func (t *TokenIssuer) Issue(subject string, roles ...string) (AccessToken, error) {
+	now := t.now()
+	expiresAt := now.Add(t.ttl)
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return AccessToken{}, fmt.Errorf("generate token id: %w", err)
+	}
+
+	header, err := json.Marshal(tokenHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	payload, err := json.Marshal(Claims{
+		ID:        hex.EncodeToString(id),
+		Subject:   subject,
+		Issuer:    t.issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Roles:     roles,
+	})
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	unsigned := encodeSegment(header) + "." + encodeSegment(payload)
+
+	return AccessToken{
+		Token:     unsigned + "." + encodeSegment(sign(t.secret, unsigned)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Verify проверяет подпись, алгоритм, издателя и срок действия токена
+func (t *TokenIssuer) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		// алгоритм фиксирован, иначе клиент мог бы подставить alg=none
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if !t.validSignature(parts[0]+"."+parts[1], signature) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Subject == "" || (t.issuer != "" && claims.Issuer != t.issuer) {
+		return nil, ErrInvalidToken
+	}
+
+	if t.now().Add(-t.leeway).Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+func (t *TokenIssuer) validSignature(unsigned string, signature []byte) bool {
+	if hmac.Equal(signature, sign(t.secret, unsigned)) {
+		return true
+	}
+
+	for _, secret := range t.previous {
+		if hmac.Equal(signature, sign(secret, unsigned)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sign(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return mac.Sum(nil)
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(s string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
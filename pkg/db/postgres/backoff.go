@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	defaultConnectAttempts     = 10
+	defaultConnectInitialDelay = 500 * time.Millisecond
+	defaultConnectMaxDelay     = 10 * time.Second
+)
+
+// ConnectWithBackoff открывает пул и дожидается доступности БД: неудачный пинг повторяется
+// не более config.ConnectAttempts раз с экспоненциально растущей задержкой (не больше
+// config.ConnectMaxDelay) и случайным разбросом config.ConnectJitter. Ошибки конфигурации
+// не повторяются. Ожидание прерывается отменой ctx.
+func ConnectWithBackoff(ctx context.Context, config Config, role UserRole, logger Logger) (*sqlx.DB, error) {
+	db, err := Connect(config, role, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := config.ConnectAttempts
+	if attempts <= 0 {
+		attempts = defaultConnectAttempts
+	}
+
+	delay := config.ConnectInitialDelay
+	if delay <= 0 {
+		delay = defaultConnectInitialDelay
+	}
+
+	maxDelay := config.ConnectMaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultConnectMaxDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = db.PingContext(ctx)
+		if err == nil {
+			if attempt > 1 && logger != nil {
+				logger.Info(ctx, fmt.Sprintf("connected to postgres as %s after %d attempts", role, attempt))
+			}
+
+			return db, nil
+		}
+
+		if attempt >= attempts || ctx.Err() != nil {
+			break
+		}
+
+		wait := withJitter(delay, config.ConnectJitter)
+		if logger != nil {
+			logger.Warning(ctx, fmt.Sprintf(
+				"postgres is not available as %s (attempt %d/%d), retrying in %s",
+				role, attempt, attempts, wait.Round(time.Millisecond),
+			), err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+		}
+
+		delay = min(delay*2, maxDelay)
+	}
+
+	_ = db.Close()
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, errors.Join(fmt.Errorf("can't ping postgres: %w", err), ctxErr)
+	}
+
+	return nil, fmt.Errorf("can't ping postgres after %d attempts: %w", attempts, err)
+}
+
+// withJitter случайно отклоняет delay на долю jitter в обе стороны
+func withJitter(delay time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return delay
+	}
+
+	jitter = min(jitter, 1)
+	spread := float64(delay) * jitter
+
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
@@ -0,0 +1,480 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// QuoteIdentifier экранирует идентификатор, части через точку экранируются по отдельности:
+// users.id -> "users"."id"
+func QuoteIdentifier(name string) string {
+	return pgx.Identifier(strings.Split(name, ".")).Sanitize()
+}
+
+// args накапливает аргументы запроса и выдает для них плейсхолдеры $1, $2, ...
+type args struct {
+	values []any
+}
+
+func (a *args) add(v any) string {
+	a.values = append(a.values, v)
+	return fmt.Sprintf("$%d", len(a.values))
+}
+
+// Cond - условие WHERE. Колонки в условиях экранируются, значения передаются аргументами.
+type Cond interface {
+	build(a *args) string
+}
+
+// Eq - равенство колонок значениям, nil сравнивается через IS NULL
+type Eq map[string]any
+
+func (c Eq) build(a *args) string {
+	return buildMapCond(c, a, "=", "IS NULL")
+}
+
+// NotEq - неравенство колонок значениям, nil сравнивается через IS NOT NULL
+type NotEq map[string]any
+
+func (c NotEq) build(a *args) string {
+	return buildMapCond(c, a, "<>", "IS NOT NULL")
+}
+
+func buildMapCond(c map[string]any, a *args, op string, nullOp string) string {
+	// порядок ключей фиксируем, чтобы запрос был детерминированным
+	columns := make([]string, 0, len(c))
+	for column := range c {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	parts := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if c[column] == nil {
+			parts = append(parts, fmt.Sprintf("%s %s", QuoteIdentifier(column), nullOp))
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf("%s %s %s", QuoteIdentifier(column), op, a.add(c[column])))
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+type compareCond struct {
+	column string
+	op     string
+	value  any
+}
+
+func (c compareCond) build(a *args) string {
+	return fmt.Sprintf("%s %s %s", QuoteIdentifier(c.column), c.op, a.add(c.value))
+}
+
+func Lt(column string, value any) Cond  { return compareCond{column: column, op: "<", value: value} }
+func Lte(column string, value any) Cond { return compareCond{column: column, op: "<=", value: value} }
+func Gt(column string, value any) Cond  { return compareCond{column: column, op: ">", value: value} }
+func Gte(column string, value any) Cond { return compareCond{column: column, op: ">=", value: value} }
+
+type inCond struct {
+	column string
+	values []any
+}
+
+// In - вхождение колонки в список значений, пустой список никогда не выполняется
+func In(column string, values ...any) Cond {
+	return inCond{column: column, values: values}
+}
+
+func (c inCond) build(a *args) string {
+	if len(c.values) == 0 {
+		return "FALSE"
+	}
+
+	placeholders := make([]string, len(c.values))
+	for i := range c.values {
+		placeholders[i] = a.add(c.values[i])
+	}
+
+	return fmt.Sprintf("%s IN (%s)", QuoteIdentifier(c.column), strings.Join(placeholders, ", "))
+}
+
+type rawCond struct {
+	sql  string
+	args []any
+}
+
+// Raw - произвольное условие с плейсхолдерами ?, которые заменяются на нумерованные.
+// Идентификаторы в sql не экранируются.
+func Raw(sql string, args ...any) Cond {
+	return rawCond{sql: sql, args: args}
+}
+
+func (c rawCond) build(a *args) string {
+	var (
+		sb  strings.Builder
+		pos int
+	)
+
+	for _, r := range c.sql {
+		if r == '?' && pos < len(c.args) {
+			sb.WriteString(a.add(c.args[pos]))
+			pos++
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+type logicalCond struct {
+	op    string
+	conds []Cond
+}
+
+func And(conds ...Cond) Cond { return logicalCond{op: "AND", conds: conds} }
+func Or(conds ...Cond) Cond  { return logicalCond{op: "OR", conds: conds} }
+
+func (c logicalCond) build(a *args) string {
+	parts := make([]string, 0, len(c.conds))
+	for _, cond := range c.conds {
+		if part := cond.build(a); part != "" {
+			parts = append(parts, "("+part+")")
+		}
+	}
+
+	return strings.Join(parts, " "+c.op+" ")
+}
+
+// Order - элемент ORDER BY
+type Order struct {
+	Column string
+	Desc   bool
+}
+
+func Asc(column string) Order  { return Order{Column: column} }
+func Desc(column string) Order { return Order{Column: column, Desc: true} }
+
+func buildWhere(conds []Cond, a *args) string {
+	if len(conds) == 0 {
+		return ""
+	}
+
+	where := And(conds...).build(a)
+	if where == "" {
+		return ""
+	}
+
+	return " WHERE " + where
+}
+
+func quoteList(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i := range columns {
+		quoted[i] = QuoteIdentifier(columns[i])
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// SelectBuilder собирает SELECT запрос. Методы изменяют builder и возвращают его же,
+// для переиспользования базового запроса используйте Clone.
+type SelectBuilder struct {
+	columns []string
+	from    string
+	where   []Cond
+	orderBy []Order
+	limit   int
+	offset  int
+}
+
+// NewSelect начинает SELECT запрос, без колонок выбираются все (*)
+func NewSelect(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns}
+}
+
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+// Where добавляет условия, объединяемые через AND
+func (b *SelectBuilder) Where(conds ...Cond) *SelectBuilder {
+	b.where = append(b.where, conds...)
+	return b
+}
+
+func (b *SelectBuilder) OrderBy(orders ...Order) *SelectBuilder {
+	b.orderBy = append(b.orderBy, orders...)
+	return b
+}
+
+func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
+	b.limit = limit
+	return b
+}
+
+func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
+	b.offset = offset
+	return b
+}
+
+func (b *SelectBuilder) Clone() *SelectBuilder {
+	clone := *b
+	clone.columns = append([]string(nil), b.columns...)
+	clone.where = append([]Cond(nil), b.where...)
+	clone.orderBy = append([]Order(nil), b.orderBy...)
+
+	return &clone
+}
+
+func (b *SelectBuilder) ToSQL() (string, []any, error) {
+	if b.from == "" {
+		return "", nil, errors.New("select: table is not set")
+	}
+
+	a := &args{}
+
+	columns := "*"
+	if len(b.columns) > 0 {
+		columns = quoteList(b.columns)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SELECT %s FROM %s", columns, QuoteIdentifier(b.from))
+	sb.WriteString(buildWhere(b.where, a))
+
+	if len(b.orderBy) > 0 {
+		orders := make([]string, len(b.orderBy))
+		for i, o := range b.orderBy {
+			orders[i] = QuoteIdentifier(o.Column)
+			if o.Desc {
+				orders[i] += " DESC"
+			}
+		}
+		sb.WriteString(" ORDER BY " + strings.Join(orders, ", "))
+	}
+
+	if b.limit > 0 {
+		sb.WriteString(" LIMIT " + a.add(b.limit))
+	}
+
+	if b.offset > 0 {
+		sb.WriteString(" OFFSET " + a.add(b.offset))
+	}
+
+	return sb.String(), a.values, nil
+}
+
+// InsertBuilder собирает INSERT запрос
+type InsertBuilder struct {
+	table     string
+	columns   []string
+	rows      [][]any
+	conflict  []string
+	doNothing bool
+	returning []string
+	err       error
+}
+
+func NewInsert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+func (b *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	b.columns = columns
+	return b
+}
+
+// Values добавляет строку, количество значений должно совпадать с количеством колонок
+func (b *InsertBuilder) Values(values ...any) *InsertBuilder {
+	if len(values) != len(b.columns) && b.err == nil {
+		b.err = fmt.Errorf("insert: %d values for %d columns", len(values), len(b.columns))
+	}
+
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// SetMap задает колонки и одну строку значений из map
+func (b *InsertBuilder) SetMap(values map[string]any) *InsertBuilder {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	row := make([]any, len(columns))
+	for i, column := range columns {
+		row[i] = values[column]
+	}
+
+	b.columns = columns
+	b.rows = [][]any{row}
+	return b
+}
+
+// OnConflictDoNothing добавляет ON CONFLICT (columns) DO NOTHING, без колонок - для любого конфликта
+func (b *InsertBuilder) OnConflictDoNothing(columns ...string) *InsertBuilder {
+	b.conflict = columns
+	b.doNothing = true
+	return b
+}
+
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b.returning = columns
+	return b
+}
+
+func (b *InsertBuilder) ToSQL() (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	if b.table == "" || len(b.columns) == 0 || len(b.rows) == 0 {
+		return "", nil, errors.New("insert: table, columns and values are required")
+	}
+
+	a := &args{}
+
+	rows := make([]string, len(b.rows))
+	for i, row := range b.rows {
+		placeholders := make([]string, len(row))
+		for j := range row {
+			placeholders[j] = a.add(row[j])
+		}
+		rows[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES %s", QuoteIdentifier(b.table), quoteList(b.columns), strings.Join(rows, ", "))
+
+	if b.doNothing {
+		sb.WriteString(" ON CONFLICT")
+		if len(b.conflict) > 0 {
+			sb.WriteString(" (" + quoteList(b.conflict) + ")")
+		}
+		sb.WriteString(" DO NOTHING")
+	}
+
+	if len(b.returning) > 0 {
+		sb.WriteString(" RETURNING " + quoteList(b.returning))
+	}
+
+	return sb.String(), a.values, nil
+}
+
+// UpdateBuilder собирает UPDATE запрос
+type UpdateBuilder struct {
+	table     string
+	set       []string
+	values    []any
+	where     []Cond
+	returning []string
+}
+
+func NewUpdate(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+func (b *UpdateBuilder) Set(column string, value any) *UpdateBuilder {
+	b.set = append(b.set, column)
+	b.values = append(b.values, value)
+	return b
+}
+
+func (b *UpdateBuilder) SetMap(values map[string]any) *UpdateBuilder {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		b.Set(column, values[column])
+	}
+
+	return b
+}
+
+// Where добавляет условия, объединяемые через AND
+func (b *UpdateBuilder) Where(conds ...Cond) *UpdateBuilder {
+	b.where = append(b.where, conds...)
+	return b
+}
+
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b.returning = columns
+	return b
+}
+
+func (b *UpdateBuilder) ToSQL() (string, []any, error) {
+	if b.table == "" || len(b.set) == 0 {
+		return "", nil, errors.New("update: table and values are required")
+	}
+
+	// UPDATE без условий почти всегда ошибка, для обновления всех строк используйте Raw("TRUE")
+	if len(b.where) == 0 {
+		return "", nil, errors.New("update: where condition is required")
+	}
+
+	a := &args{}
+
+	set := make([]string, len(b.set))
+	for i := range b.set {
+		set[i] = fmt.Sprintf("%s = %s", QuoteIdentifier(b.set[i]), a.add(b.values[i]))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "UPDATE %s SET %s", QuoteIdentifier(b.table), strings.Join(set, ", "))
+	sb.WriteString(buildWhere(b.where, a))
+
+	if len(b.returning) > 0 {
+		sb.WriteString(" RETURNING " + quoteList(b.returning))
+	}
+
+	return sb.String(), a.values, nil
+}
+
+// Builder реализуется SelectBuilder, InsertBuilder и UpdateBuilder
+type Builder interface {
+	ToSQL() (string, []any, error)
+}
+
+// GetBuilt выполняет собранный запрос через Get
+func GetBuilt[T any](ctx context.Context, q Queryer, b Builder) (T, error) {
+	var zero T
+
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return zero, err
+	}
+
+	return Get[T](ctx, q, query, args...)
+}
+
+// SelectBuilt выполняет собранный запрос через Select
+func SelectBuilt[T any](ctx context.Context, q Queryer, b Builder) ([]T, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	return Select[T](ctx, q, query, args...)
+}
+
+// ExecBuilt выполняет собранный запрос через Exec
+func ExecBuilt(ctx context.Context, e Execer, b Builder) (int64, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	return Exec(ctx, e, query, args...)
+}
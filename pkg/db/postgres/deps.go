@@ -0,0 +1,11 @@
+package postgres
+
+import "context"
+
+type Logger interface {
+	Debug(ctx context.Context, args ...interface{})
+	Info(ctx context.Context, args ...interface{})
+	Warning(ctx context.Context, args ...interface{})
+	Error(ctx context.Context, args ...interface{})
+	WithFields(ctx context.Context, fields map[string]interface{}) context.Context
+}
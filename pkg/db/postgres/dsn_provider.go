@@ -3,30 +3,32 @@ package postgres
 import (
 	"errors"
 	"fmt"
-	"github.com/siyoga/rollstory/pkg/env"
 	"os"
 )
 
 type DSNProvider struct {
+	config Config
 }
 
-func NewDSNProvider() DSNProvider {
-	return DSNProvider{}
+func NewDSNProvider(config Config) DSNProvider {
+	return DSNProvider{
+		config: config,
+	}
 }
 
-func (p *DSNProvider) IsAvailable(databaseENV databaseENV) bool {
-	_, err := p.readENV(databaseENV)
+func (p *DSNProvider) IsAvailable() bool {
+	_, err := p.readConfig()
 
 	return err == nil
 }
 
-func (p *DSNProvider) Provide(databaseENV databaseENV) (*DSN, error) {
-	parsedDSN, err := p.readENV(databaseENV)
+func (p *DSNProvider) Provide(role UserRole) (*DSN, error) {
+	parsedDSN, err := p.readConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse ENV: %w", err)
+		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
-	dsn, err := p.buildDSN(parsedDSN, int(UserRoleFullAccess))
+	dsn, err := p.buildDSN(parsedDSN, int(role))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build DSN: %w", err)
 	}
@@ -34,42 +36,46 @@ func (p *DSNProvider) Provide(databaseENV databaseENV) (*DSN, error) {
 	return dsn, nil
 }
 
-func (p *DSNProvider) readENV(databaseENV databaseENV) (databaseDSN, error) {
-	errs := make([]error, 0, 7) // 7 env vars
+func (p *DSNProvider) readConfig() (databaseDSN, error) {
+	errs := make([]error, 0, 5) // 5 обязательных параметров
 
-	host, err := env.LookupEnvString(databaseENV.Host)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.Host))
+	if p.config.Host == "" {
+		errs = append(errs, errors.New("host not set"))
 	}
 
-	port, err := env.LookupEnvInt(databaseENV.Port)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.Port))
+	if p.config.Port == 0 {
+		errs = append(errs, errors.New("port not set"))
 	}
 
-	dbName, err := env.LookupEnvString(databaseENV.DbName)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.DbName))
+	if p.config.DBName == "" {
+		errs = append(errs, errors.New("database name not set"))
 	}
 
-	user, err := env.LookupEnvString(databaseENV.User)
-	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.User))
+	if p.config.User == "" {
+		errs = append(errs, errors.New("user not set"))
 	}
 
-	password, err := env.LookupEnvString(databaseENV.Password)
+	if p.config.Password == "" {
+		errs = append(errs, errors.New("password not set"))
+	}
+
+	rootCert, err := loadPEM(p.config.SSLRootCert, p.config.SSLRootCertPEM)
 	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.Password))
+		errs = append(errs, fmt.Errorf("root certificate: %w", err))
 	}
 
-	sslMode, err := env.LookupEnvString(databaseENV.SSLMode)
+	cert, err := loadPEM(p.config.SSLCert, p.config.SSLCertPEM)
 	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.SSLMode))
+		errs = append(errs, fmt.Errorf("client certificate: %w", err))
 	}
 
-	connectTimeout, err := env.LookupEnvInt(databaseENV.ConnectTimeout)
+	key, err := loadPEM(p.config.SSLKey, p.config.SSLKeyPEM)
 	if err != nil {
-		errs = append(errs, fmt.Errorf("environment variable %s not set", databaseENV.ConnectTimeout))
+		errs = append(errs, fmt.Errorf("client key: %w", err))
+	}
+
+	if (cert == nil) != (key == nil) {
+		errs = append(errs, errors.New("both client certificate and key are required"))
 	}
 
 	if len(errs) != 0 {
@@ -78,27 +84,55 @@ func (p *DSNProvider) readENV(databaseENV databaseENV) (databaseDSN, error) {
 
 	return databaseDSN{
 		Name:           "",
-		Host:           host,
-		Port:           port,
-		DbName:         dbName,
-		SSLMode:        sslMode,
-		ConnectTimeout: connectTimeout,
+		Host:           p.config.Host,
+		Port:           p.config.Port,
+		ReplicaHost:    p.config.ReplicaHost,
+		ReplicaPort:    p.config.ReplicaPort,
+		DbName:         p.config.DBName,
+		SSLMode:        string(p.config.SSLMode),
+		SSLRootCert:    rootCert,
+		SSLCert:        cert,
+		SSLKey:         key,
+		ConnectTimeout: p.config.ConnectTimeout,
 		User: databaseUsers{
+			ReadOnly:  optionalUser(p.config.ReadOnlyUser, p.config.ReadOnlyPassword),
+			ReadWrite: optionalUser(p.config.ReadWriteUser, p.config.ReadWritePassword),
 			FullAccess: &databaseUser{
-				Login:    user,
-				Password: password,
+				Login:    p.config.User,
+				Password: p.config.Password,
 			},
 		},
 	}, nil
 }
 
+func optionalUser(login, password string) *databaseUser {
+	if login == "" {
+		return nil
+	}
+
+	return &databaseUser{
+		Login:    login,
+		Password: password,
+	}
+}
+
 func (p *DSNProvider) buildDSN(config databaseDSN, userRole int) (*DSN, error) {
-	// пока один дефолтный юзер на full access права
+	// если пользователь для роли не задан, берем пользователя с большими правами
 	var u *databaseUser
-	switch userRole {
-	case int(UserRoleFullAccess):
+	switch UserRole(userRole) {
+	case UserRoleReadOnly:
+		u = config.User.ReadOnly
+		if u != nil {
+			break
+		}
 		fallthrough
-	default:
+	case UserRoleReadWrite:
+		u = config.User.ReadWrite
+		if u != nil {
+			break
+		}
+		fallthrough
+	case UserRoleFullAccess:
 		u = config.User.FullAccess
 	}
 
@@ -106,6 +140,14 @@ func (p *DSNProvider) buildDSN(config databaseDSN, userRole int) (*DSN, error) {
 		return nil, fmt.Errorf("invalid user role %s", UserRole(userRole))
 	}
 
+	host, port := config.Host, config.Port
+	if UserRole(userRole) == UserRoleReadOnly && config.ReplicaHost != "" {
+		host = config.ReplicaHost
+		if config.ReplicaPort != 0 {
+			port = config.ReplicaPort
+		}
+	}
+
 	userLogin := u.Login
 	if envVal := os.ExpandEnv(userLogin); envVal != "" {
 		userLogin = envVal
@@ -118,14 +160,17 @@ func (p *DSNProvider) buildDSN(config databaseDSN, userRole int) (*DSN, error) {
 
 	dsn := DSN{
 		Name:           config.Name,
-		DBName:         config.Name,
-		Host:           config.Host,
-		Port:           config.Port,
+		DBName:         config.DbName,
+		Host:           host,
+		Port:           port,
 		ConnectTimeout: config.ConnectTimeout,
 		UserLogin:      userLogin,
 		UserPassword:   userPassword,
 		UserRole:       userRole,
 		SSLMode:        config.SSLMode,
+		SSLRootCert:    config.SSLRootCert,
+		SSLCert:        config.SSLCert,
+		SSLKey:         config.SSLKey,
 	}
 
 	return &dsn, nil
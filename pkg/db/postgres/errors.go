@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+)
+
+// SQLSTATE ошибок, которые обрабатываются отдельно
+const (
+	codeUniqueViolation      = "23505"
+	codeForeignKeyViolation  = "23503"
+	codeNotNullViolation     = "23502"
+	codeCheckViolation       = "23514"
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrUniqueViolation     = errors.New("unique violation")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrNotNullViolation    = errors.New("not null violation")
+	ErrCheckViolation      = errors.New("check violation")
+)
+
+// ConstraintError - нарушение ограничения целостности. errors.Is срабатывает
+// на соответствующую Err* ошибку, errors.As - на исходный *pgconn.PgError.
+type ConstraintError struct {
+	Kind       error
+	Table      string
+	Column     string
+	Constraint string
+
+	pgErr *pgconn.PgError
+}
+
+func (e *ConstraintError) Error() string {
+	switch {
+	case e.Constraint != "":
+		return fmt.Sprintf("%s on constraint %s", e.Kind, e.Constraint)
+	case e.Column != "":
+		return fmt.Sprintf("%s on column %s.%s", e.Kind, e.Table, e.Column)
+	default:
+		return e.Kind.Error()
+	}
+}
+
+func (e *ConstraintError) Unwrap() []error {
+	return []error{e.Kind, e.pgErr}
+}
+
+// LoggerFields добавляет детали ошибки в лог, см. logger.WithError
+func (e *ConstraintError) LoggerFields() map[string]any {
+	return map[string]any{
+		"db_table":      e.Table,
+		"db_column":     e.Column,
+		"db_constraint": e.Constraint,
+		"db_detail":     e.pgErr.Detail,
+	}
+}
+
+// MapError приводит ошибки драйвера к ошибкам пакета: sql.ErrNoRows к ErrNotFound,
+// нарушения ограничений к *ConstraintError. Остальные ошибки возвращаются как есть.
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	var kind error
+	switch pgErr.Code {
+	case codeUniqueViolation:
+		kind = ErrUniqueViolation
+	case codeForeignKeyViolation:
+		kind = ErrForeignKeyViolation
+	case codeNotNullViolation:
+		kind = ErrNotNullViolation
+	case codeCheckViolation:
+		kind = ErrCheckViolation
+	default:
+		return err
+	}
+
+	return &ConstraintError{
+		Kind:       kind,
+		Table:      pgErr.TableName,
+		Column:     pgErr.ColumnName,
+		Constraint: pgErr.ConstraintName,
+		pgErr:      pgErr,
+	}
+}
@@ -0,0 +1,46 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var nameSanitizeRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create создает в dir пару пустых файлов миграции со следующей по порядку версией
+// и возвращает пути до них
+func Create(dir string, name string) (up string, down string, err error) {
+	name = strings.Trim(nameSanitizeRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", fmt.Errorf("migration name must contain latin letters or digits")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", version, name))
+	up, down = base+".up.sql", base+".down.sql"
+
+	for _, file := range []string{up, down} {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("create migration file: %w", err)
+		}
+
+		if err := f.Close(); err != nil {
+			return "", "", fmt.Errorf("create migration file: %w", err)
+		}
+	}
+
+	return up, down, nil
+}
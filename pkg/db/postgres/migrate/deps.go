@@ -0,0 +1,7 @@
+package migrate
+
+import "context"
+
+type Logger interface {
+	Info(ctx context.Context, args ...interface{})
+}
@@ -0,0 +1,296 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	defaultTable = "schema_migrations"
+	// defaultLockID - произвольный ключ advisory lock, общий для всех инстансов сервиса
+	defaultLockID int64 = 7_310_442_950_118
+
+	// SQLSTATE undefined_table
+	codeUndefinedTable = "42P01"
+)
+
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Status описывает состояние одной миграции
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	options
+
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+// New создает Migrator для миграций из fsys
+func New(db *sqlx.DB, fsys fs.FS, opts ...Option) (*Migrator, error) {
+	o := options{
+		table:  defaultTable,
+		lockID: defaultLockID,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		options:    o,
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up применяет не более steps неприменённых миграций, steps <= 0 применяет все.
+// Возвращает применённые миграции.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if steps > 0 && len(applied) >= steps {
+				break
+			}
+
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration, directionUp); err != nil {
+				return err
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down откатывает последние steps применённых миграций, steps <= 0 откатывает все.
+// Возвращает откаченные миграции.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if steps > 0 && len(reverted) >= steps {
+				break
+			}
+
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("%d_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+
+			if err := m.apply(ctx, conn, migration, directionDown); err != nil {
+				return err
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status возвращает состояние всех известных миграций
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// withLock выполняет fn на выделенном соединении под сессионным advisory lock,
+// чтобы несколько инстансов не применяли миграции одновременно
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) (err error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		// контекст мог быть отменён, но лок нужно отпустить в любом случае
+		if _, unlockErr := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", m.lockID); unlockErr != nil && err == nil {
+			err = fmt.Errorf("release migration lock: %w", unlockErr)
+		}
+	}()
+
+	// в режиме dry-run схему не меняем, даже служебную таблицу
+	if !m.dryRun {
+		if err := m.ensureTable(ctx, conn); err != nil {
+			return err
+		}
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, conn *sqlx.Conn) error {
+	_, err := conn.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version    BIGINT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, m.table))
+	if err != nil {
+		return fmt.Errorf("create %s table: %w", m.table, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sqlx.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("SELECT version, applied_at FROM %s", m.table))
+	if err != nil {
+		// таблица ещё не создана - ни одна миграция не применена
+		if isUndefinedTable(err) {
+			return map[int64]time.Time{}, nil
+		}
+
+		return nil, fmt.Errorf("read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, migration Migration, dir direction) error {
+	query := migration.Up
+	if dir == directionDown {
+		query = migration.Down
+	}
+
+	if m.logger != nil {
+		msg := fmt.Sprintf("migrate %s %d_%s", dir, migration.Version, migration.Name)
+		if m.dryRun {
+			msg = fmt.Sprintf("[dry-run] %s\n%s", msg, query)
+		}
+		m.logger.Info(ctx, msg)
+	}
+
+	if m.dryRun {
+		return nil
+	}
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	if err := m.applyTx(ctx, tx, migration, query, dir); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("migrate %s %d_%s: %w", dir, migration.Version, migration.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) applyTx(ctx context.Context, tx *sqlx.Tx, migration Migration, query string, dir direction) error {
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	var err error
+	switch dir {
+	case directionUp:
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version, name) VALUES ($1, $2)", m.table), migration.Version, migration.Name)
+	case directionDown:
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE version = $1", m.table), migration.Version)
+	}
+
+	if err != nil {
+		return fmt.Errorf("update %s: %w", m.table, err)
+	}
+
+	return nil
+}
+
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == codeUndefinedTable
+}
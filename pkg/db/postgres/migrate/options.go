@@ -0,0 +1,37 @@
+package migrate
+
+type Option func(*options)
+
+type options struct {
+	table  string
+	lockID int64
+	dryRun bool
+	logger Logger
+}
+
+// WithTable задает имя таблицы с примененными миграциями
+func WithTable(table string) Option {
+	return func(o *options) {
+		o.table = table
+	}
+}
+
+// WithLockID задает ключ pg_advisory_lock, которым защищается применение миграций
+func WithLockID(id int64) Option {
+	return func(o *options) {
+		o.lockID = id
+	}
+}
+
+// WithDryRun включает режим, в котором SQL миграций только логируется, но не выполняется
+func WithDryRun(dryRun bool) Option {
+	return func(o *options) {
+		o.dryRun = dryRun
+	}
+}
+
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
@@ -6,21 +6,28 @@ import (
 	"net/url"
 )
 
-func buildOptions(dsnProvider DSNProvider) (*Options, error) {
-	options := &Options{}
+func buildOptions(dsnProvider DSNProvider, role UserRole, logger Logger) (*Options, error) {
+	options := &Options{
+		driverName:      defaultDriverName,
+		MaxOpenConns:    dsnProvider.config.MaxOpenConns,
+		MaxIdleConns:    dsnProvider.config.MaxIdleConns,
+		ConnMaxLifetime: dsnProvider.config.ConnMaxLifetime,
+		ConnMaxIdleTime: dsnProvider.config.ConnMaxIdleTime,
+	}
 
-	env := databaseENV{
-		Host:           "PG_HOST",
-		Port:           "PG_PORT",
-		DbName:         "PG_DBNAME",
-		User:           "PG_USER",
-		Password:       "PG_PASSWORD",
-		SSLMode:        "PG_SSLMODE",
-		ConnectTimeout: "PG_CONNECT_TIMEOUT",
+	if logger != nil {
+		logLevel, err := pgx.LogLevelFromString(dsnProvider.config.LogLevel)
+		if err != nil {
+			return nil, fmt.Errorf("when parsing log level %q: %w", dsnProvider.config.LogLevel, err)
+		}
+
+		queryLogger := NewQueryLogger(logger, logLevel, dsnProvider.config.SlowQueryThreshold)
+		options.Logger = queryLogger
+		options.LogLevel = queryLogger.PgxLogLevel()
 	}
 
-	if dsnProvider.IsAvailable(env) {
-		dsn, err := dsnProvider.Provide(env)
+	if dsnProvider.IsAvailable() {
+		dsn, err := dsnProvider.Provide(role)
 		if err != nil {
 			return nil, fmt.Errorf("when providing dsn: %w", err)
 		}
@@ -33,8 +40,8 @@ func buildOptions(dsnProvider DSNProvider) (*Options, error) {
 	return options, nil
 }
 
-func buildConnectionConfigWithOptions(dsnProvider DSNProvider) (*pgx.ConnConfig, *Options, error) {
-	options, err := buildOptions(dsnProvider)
+func buildConnectionConfigWithOptions(dsnProvider DSNProvider, role UserRole, logger Logger) (*pgx.ConnConfig, *Options, error) {
+	options, err := buildOptions(dsnProvider, role, logger)
 	if err != nil {
 		return nil, nil, fmt.Errorf("when building connection config: %w", err)
 	}
@@ -46,6 +53,10 @@ func buildConnectionConfigWithOptions(dsnProvider DSNProvider) (*pgx.ConnConfig,
 
 	useOptions(options, connConfig)
 
+	if err := useTLS(options, connConfig); err != nil {
+		return nil, nil, fmt.Errorf("when configuring tls: %w", err)
+	}
+
 	return connConfig, options, nil
 }
 
@@ -84,6 +95,11 @@ func parseDSNToOptions(dsn *DSN, options *Options) error {
 		return fmt.Errorf("dsn is nil")
 	}
 
+	sslMode, err := ParseSSLMode(dsn.SSLMode)
+	if err != nil {
+		return err
+	}
+
 	options.user = dsn.UserLogin
 	options.password = dsn.UserPassword
 	options.dsnName = dsn.Name
@@ -91,7 +107,10 @@ func parseDSNToOptions(dsn *DSN, options *Options) error {
 	options.port = dsn.Port
 	options.dbName = dsn.DBName
 	options.userRole = UserRole(dsn.UserRole)
-	options.sslMode = SSLModeDisabled
+	options.sslMode = sslMode
+	options.sslRootCert = dsn.SSLRootCert
+	options.sslCert = dsn.SSLCert
+	options.sslKey = dsn.SSLKey
 	options.connectTimeout = dsn.ConnectTimeout
 
 	return nil
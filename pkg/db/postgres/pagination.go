@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 1000
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Keyset описывает ключ пагинации: набор колонок, уникально упорядочивающих строки
+// (последней обычно идет первичный ключ), и функцию извлечения их значений из строки.
+type Keyset[T any] struct {
+	Columns []string
+	Desc    bool
+	Values  func(item T) []any
+}
+
+// PageRequest - запрос страницы. After - курсор из Page.Next предыдущей страницы,
+// пустой для первой страницы.
+type PageRequest struct {
+	After string
+	Limit int
+}
+
+// Page - страница результатов. Next пустой, если страница последняя.
+type Page[T any] struct {
+	Items []T
+	Next  string
+}
+
+// SelectPage выполняет запрос b постранично по ключу keyset. Сортировка и лимит
+// задаются SelectPage, поэтому в b их быть не должно; сам b не изменяется.
+func SelectPage[T any](ctx context.Context, q Queryer, b *SelectBuilder, keyset Keyset[T], page PageRequest) (Page[T], error) {
+	if len(keyset.Columns) == 0 || keyset.Values == nil {
+		return Page[T]{}, errors.New("select page: keyset columns and values are required")
+	}
+
+	limit := page.Limit
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	limit = min(limit, maxPageLimit)
+
+	b = b.Clone()
+
+	if page.After != "" {
+		values, err := decodeCursor(page.After, len(keyset.Columns))
+		if err != nil {
+			return Page[T]{}, err
+		}
+
+		b.Where(keysetCond(keyset.Columns, keyset.Desc, values))
+	}
+
+	for _, column := range keyset.Columns {
+		b.OrderBy(Order{Column: column, Desc: keyset.Desc})
+	}
+
+	// запрашиваем на одну строку больше, чтобы узнать, есть ли следующая страница
+	items, err := SelectBuilt[T](ctx, q, b.Limit(limit+1))
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	if len(items) <= limit {
+		return Page[T]{Items: items}, nil
+	}
+
+	items = items[:limit]
+
+	next, err := encodeCursor(keyset.Values(items[limit-1]))
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	return Page[T]{Items: items, Next: next}, nil
+}
+
+// keysetCond строит сравнение строк (a, b) > ($1, $2), которое использует составной индекс
+func keysetCond(columns []string, desc bool, values []string) Cond {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+
+	placeholders := make([]string, len(columns))
+	args := make([]any, len(values))
+	for i := range columns {
+		placeholders[i] = "?"
+		args[i] = values[i]
+	}
+
+	return Raw(fmt.Sprintf("(%s) %s (%s)", quoteList(columns), op, strings.Join(placeholders, ", ")), args...)
+}
+
+// Значения курсора хранятся строками: pgx передает строковые аргументы в текстовом формате,
+// и Postgres сам приводит их к типу колонки.
+func encodeCursor(values []any) (string, error) {
+	encoded := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case time.Time:
+			encoded[i] = v.Format(time.RFC3339Nano)
+		case fmt.Stringer:
+			encoded[i] = v.String()
+		default:
+			encoded[i] = fmt.Sprint(v)
+		}
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string, size int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if len(values) != size {
+		return nil, fmt.Errorf("%w: expected %d values, got %d", ErrInvalidCursor, size, len(values))
+	}
+
+	return values, nil
+}
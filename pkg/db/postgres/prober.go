@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	defaultProbeInterval = 15 * time.Second
+	defaultProbeTimeout  = 2 * time.Second
+)
+
+// PoolStats - статистика пула соединений вместе с результатами фоновых проверок
+type PoolStats struct {
+	sql.DBStats
+
+	Healthy             bool
+	ConsecutiveFailures int
+	TotalChecks         int64
+	TotalFailures       int64
+	LastLatency         time.Duration
+	LastError           string
+	LastCheckedAt       time.Time
+}
+
+type ProberOption func(*Prober)
+
+// WithProbeInterval задает периодичность фоновых проверок
+func WithProbeInterval(interval time.Duration) ProberOption {
+	return func(p *Prober) {
+		if interval > 0 {
+			p.interval = interval
+		}
+	}
+}
+
+// WithProbeTimeout задает таймаут одной проверки
+func WithProbeTimeout(timeout time.Duration) ProberOption {
+	return func(p *Prober) {
+		if timeout > 0 {
+			p.timeout = timeout
+		}
+	}
+}
+
+// Prober периодически пингует БД и накапливает статистику доступности и задержек
+type Prober struct {
+	db       *sqlx.DB
+	interval time.Duration
+	timeout  time.Duration
+
+	mu    sync.RWMutex
+	stats PoolStats
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewProber(db *sqlx.DB, opts ...ProberOption) *Prober {
+	p := &Prober{
+		db:       db,
+		interval: defaultProbeInterval,
+		timeout:  defaultProbeTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Start запускает фоновые проверки, первая выполняется сразу
+func (p *Prober) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stop != nil {
+		return
+	}
+
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+
+	go p.run(p.stop, p.done)
+}
+
+// Stop останавливает фоновые проверки и дожидается завершения текущей
+func (p *Prober) Stop() {
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	p.stop, p.done = nil, nil
+	p.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+func (p *Prober) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		_ = p.Check(context.Background())
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check выполняет одну проверку и обновляет статистику
+func (p *Prober) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := p.db.PingContext(ctx)
+	latency := time.Since(start)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stats.TotalChecks++
+	p.stats.LastLatency = latency
+	p.stats.LastCheckedAt = start
+	p.stats.Healthy = err == nil
+
+	if err != nil {
+		p.stats.ConsecutiveFailures++
+		p.stats.TotalFailures++
+		p.stats.LastError = err.Error()
+	} else {
+		p.stats.ConsecutiveFailures = 0
+		p.stats.LastError = ""
+	}
+
+	return err
+}
+
+// Stats возвращает статистику пула и последних проверок
+func (p *Prober) Stats() PoolStats {
+	p.mu.RLock()
+	stats := p.stats
+	p.mu.RUnlock()
+
+	stats.DBStats = p.db.Stats()
+
+	return stats
+}
@@ -7,11 +7,12 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func Connect() (*sqlx.DB, error) {
-	dsnProvider := NewDSNProvider()
+// Connect открывает отдельный пул соединений под пользователем указанной роли.
+// Если передан logger, запросы логируются через QueryLogger.
+func Connect(config Config, role UserRole, logger Logger) (*sqlx.DB, error) {
+	dsnProvider := NewDSNProvider(config)
 
-	// мб провайдить env снаружи, а не делать дефолт значения
-	connConfig, options, err := buildConnectionConfigWithOptions(dsnProvider)
+	connConfig, options, err := buildConnectionConfigWithOptions(dsnProvider, role, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -25,5 +26,10 @@ func initConnect(connConfig *pgx.ConnConfig, options *Options) (*sqlx.DB, error)
 		return nil, err
 	}
 
+	db.SetMaxOpenConns(options.MaxOpenConns)
+	db.SetMaxIdleConns(options.MaxIdleConns)
+	db.SetConnMaxLifetime(options.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(options.ConnMaxIdleTime)
+
 	return sqlx.NewDb(db, options.driverName), nil
 }
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+// Queryer реализуется *sqlx.DB, *sqlx.Tx и соединениями из internal/inf/postgres/public
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Execer реализуется *sqlx.DB, *sqlx.Tx и соединениями из internal/inf/postgres/public
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Get выполняет запрос и сканирует первую строку в T. Структуры сопоставляются с колонками
+// по тегу db, остальные типы сканируются напрямую. Если строк нет, возвращает ErrNotFound.
+func Get[T any](ctx context.Context, q Queryer, query string, args ...any) (T, error) {
+	var zero T
+
+	items, err := Select[T](ctx, q, query, args...)
+	if err != nil {
+		return zero, err
+	}
+
+	if len(items) == 0 {
+		return zero, MapError(sql.ErrNoRows)
+	}
+
+	return items[0], nil
+}
+
+// Select выполняет запрос и сканирует все строки в []T, см. Get
+func Select[T any](ctx context.Context, q Queryer, query string, args ...any) ([]T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	// закрытие возвращает соединение в пул, а транзакции из контекста позволяет выполнять следующие запросы
+	defer rows.Close()
+
+	items := make([]T, 0)
+	if !scannable(reflect.TypeFor[T]()) {
+		if err := sqlx.StructScan(rows, &items); err != nil {
+			return nil, MapError(err)
+		}
+
+		return items, nil
+	}
+
+	for rows.Next() {
+		var item T
+		if err := rows.Scan(&item); err != nil {
+			return nil, MapError(err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, MapError(err)
+	}
+
+	return items, nil
+}
+
+// scannable сообщает, что значение T сканируется из единственной колонки напрямую:
+// это не структура, sql.Scanner или структура без экспортируемых полей вроде time.Time
+func scannable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(scannerType) || t.Kind() != reflect.Struct {
+		return true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Exec выполняет запрос и возвращает количество затронутых строк
+func Exec(ctx context.Context, e Execer, query string, args ...any) (int64, error) {
+	res, err := e.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, MapError(err)
+	}
+
+	return res.RowsAffected()
+}
+
+// NamedGet - Get с именованными параметрами вида :name, значения берутся из структуры
+// (по тегу db) или map[string]any
+func NamedGet[T any](ctx context.Context, q Queryer, query string, arg any) (T, error) {
+	var zero T
+
+	query, args, err := bindNamed(query, arg)
+	if err != nil {
+		return zero, err
+	}
+
+	return Get[T](ctx, q, query, args...)
+}
+
+// NamedSelect - Select с именованными параметрами, см. NamedGet
+func NamedSelect[T any](ctx context.Context, q Queryer, query string, arg any) ([]T, error) {
+	query, args, err := bindNamed(query, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return Select[T](ctx, q, query, args...)
+}
+
+// NamedExec - Exec с именованными параметрами, см. NamedGet
+func NamedExec(ctx context.Context, e Execer, query string, arg any) (int64, error) {
+	query, args, err := bindNamed(query, arg)
+	if err != nil {
+		return 0, err
+	}
+
+	return Exec(ctx, e, query, args...)
+}
+
+func bindNamed(query string, arg any) (string, []any, error) {
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return "", nil, fmt.Errorf("bind named query: %w", err)
+	}
+
+	return sqlx.Rebind(sqlx.DOLLAR, query), args, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const defaultSlowQueryThreshold = 500 * time.Millisecond
+
+// Поля, которые pgx передает в data при логировании запросов
+const (
+	pgxDataSQL        = "sql"
+	pgxDataArgs       = "args"
+	pgxDataTime       = "time"
+	pgxDataRowCount   = "rowCount"
+	pgxDataCommandTag = "commandTag"
+	pgxDataErr        = "err"
+)
+
+// Поля, с которыми запрос попадает в лог
+const (
+	fieldQuerySQL      = "db_query"
+	fieldQueryArgs     = "db_args"
+	fieldQueryDuration = "db_duration_ms"
+	fieldQueryRows     = "db_rows"
+)
+
+// QueryLogger реализует pgx.Logger поверх Logger. Запросы и служебные сообщения pgx
+// логируются с уровнем debug, а запросы, превысившие порог, - с уровнем warning. Значения аргументов не логируются, только их типы.
+// Поля из контекста запроса попадают в лог, так как pgx передает контекст вызова.
+// Сообщения подробнее level отбрасываются, кроме медленных запросов: они логируются при любом level.
+type QueryLogger struct {
+	logger        Logger
+	level         pgx.LogLevel
+	slowThreshold time.Duration
+}
+
+var _ pgx.Logger = (*QueryLogger)(nil)
+
+// NewQueryLogger создает адаптер, slowThreshold <= 0 использует порог по умолчанию
+func NewQueryLogger(logger Logger, level pgx.LogLevel, slowThreshold time.Duration) *QueryLogger {
+	if slowThreshold <= 0 {
+		slowThreshold = defaultSlowQueryThreshold
+	}
+
+	return &QueryLogger{
+		logger:        logger,
+		level:         level,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// PgxLogLevel - уровень, который задается pgx. Запросы pgx передает с уровнем info,
+// поэтому он не ниже info, иначе медленные запросы не дошли бы до QueryLogger.
+func (l *QueryLogger) PgxLogLevel() pgx.LogLevel {
+	return max(l.level, pgx.LogLevelInfo)
+}
+
+func (l *QueryLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	_, isQuery := data[pgxDataSQL]
+	duration, _ := data[pgxDataTime].(time.Duration)
+	slow := isQuery && duration >= l.slowThreshold
+
+	if level > l.level && !slow {
+		return
+	}
+
+	fields := make(map[string]interface{}, len(data))
+
+	for k, v := range data {
+		switch k {
+		case pgxDataSQL:
+			fields[fieldQuerySQL] = v
+		case pgxDataArgs:
+			fields[fieldQueryArgs] = redactArgs(v)
+		case pgxDataTime:
+			if d, ok := v.(time.Duration); ok {
+				fields[fieldQueryDuration] = d.Milliseconds()
+			}
+		case pgxDataRowCount:
+			fields[fieldQueryRows] = v
+		case pgxDataCommandTag:
+			if tag, ok := v.(pgconn.CommandTag); ok {
+				fields[fieldQueryRows] = tag.RowsAffected()
+			}
+		case pgxDataErr:
+			// ошибка передается отдельным аргументом, чтобы попасть в поле error
+		default:
+			fields["pgx_"+k] = v
+		}
+	}
+
+	ctx = l.logger.WithFields(ctx, fields)
+
+	var args []interface{}
+	if err, ok := data[pgxDataErr].(error); ok {
+		args = []interface{}{msg, err}
+	} else {
+		args = []interface{}{msg}
+	}
+
+	switch {
+	case level == pgx.LogLevelError:
+		l.logger.Error(ctx, args...)
+	case slow:
+		args[0] = fmt.Sprintf("slow query (%s > %s): %s", duration.Round(time.Millisecond), l.slowThreshold, msg)
+		l.logger.Warning(ctx, args...)
+	case level == pgx.LogLevelWarn:
+		l.logger.Warning(ctx, args...)
+	default:
+		l.logger.Debug(ctx, args...)
+	}
+}
+
+// redactArgs заменяет значения аргументов запроса их типами
+func redactArgs(v interface{}) interface{} {
+	args, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	redacted := make([]string, len(args))
+	for i := range args {
+		redacted[i] = fmt.Sprintf("<%T>", args[i])
+	}
+
+	return redacted
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// loadPEM читает PEM из файла path, а если путь не задан - возвращает inline содержимое
+func loadPEM(path string, inline string) ([]byte, error) {
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+
+		return content, nil
+	}
+
+	if inline != "" {
+		return []byte(inline), nil
+	}
+
+	return nil, nil
+}
+
+// useTLS заменяет TLS конфигурацию, построенную pgx по sslmode, на конфигурацию
+// с сертификатами из Options. Фоллбеки для allow и prefer сохраняют свой порядок.
+func useTLS(o *Options, config *pgx.ConnConfig) error {
+	if o.sslMode == "" || o.sslMode == SSLModeDisabled {
+		return nil
+	}
+
+	tlsConfig, err := buildTLSConfig(o.sslMode, config.Host, o.sslRootCert, o.sslCert, o.sslKey)
+	if err != nil {
+		return err
+	}
+
+	if config.TLSConfig != nil {
+		config.TLSConfig = tlsConfig
+	}
+
+	for _, fallback := range config.Fallbacks {
+		if fallback.TLSConfig != nil {
+			fallback.TLSConfig = tlsConfig
+		}
+	}
+
+	return nil
+}
+
+// buildTLSConfig повторяет семантику libpq:
+//   - allow, prefer, require - сертификат сервера не проверяется, но при заданном
+//     корневом сертификате require ведет себя как verify-ca
+//   - verify-ca - проверяется цепочка сертификатов без имени хоста
+//   - verify-full - проверяется цепочка и имя хоста
+func buildTLSConfig(mode SSLModeType, host string, rootCert, cert, key []byte) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(rootCert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(rootCert) {
+			return nil, errors.New("unable to add root certificate to cert pool")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(cert) > 0 || len(key) > 0 {
+		keyPair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	// SNI не передается для IP адресов (RFC 6066)
+	if net.ParseIP(host) == nil {
+		tlsConfig.ServerName = host
+	}
+
+	switch mode {
+	case SSLModeAllow, SSLModePrefer:
+		tlsConfig.InsecureSkipVerify = true
+	case SSLModeRequire:
+		tlsConfig.InsecureSkipVerify = true
+		if tlsConfig.RootCAs != nil {
+			tlsConfig.VerifyPeerCertificate = verifyChain(tlsConfig.RootCAs)
+		}
+	case SSLModeVerifyCA:
+		if tlsConfig.RootCAs == nil {
+			return nil, fmt.Errorf("sslmode %s requires root certificate", mode)
+		}
+
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = verifyChain(tlsConfig.RootCAs)
+	case SSLModeVerifyFull:
+		tlsConfig.ServerName = host
+	default:
+		return nil, fmt.Errorf("unsupported sslmode %q", mode)
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyChain проверяет цепочку сертификатов сервера без сверки имени хоста
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("server did not provide a certificate")
+		}
+
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i := range rawCerts {
+			cert, err := x509.ParseCertificate(rawCerts[i])
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate: %w", err)
+			}
+			certs[i] = cert
+		}
+
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: x509.NewCertPool(),
+		}
+
+		for _, cert := range certs[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+
+		_, err := certs[0].Verify(opts)
+		return err
+	}
+}
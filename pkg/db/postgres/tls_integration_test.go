@@ -0,0 +1,239 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/internal/testcert"
+)
+
+// Интеграционный тест поднимает локальный Postgres (initdb и pg_ctl из PATH или PG_BIN_DIR)
+// с самоподписанными сертификатами и требует сертификат клиента (метод cert в pg_hba.conf).
+// Запуск: go test -tags integration ./pkg/db/postgres/
+func TestTLSIntegration(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := testcert.NewCA(t, "rollstory-test-ca")
+	otherCA := testcert.NewCA(t, "other-ca")
+
+	// сертификат выпущен только на localhost, подключение по 127.0.0.1 не проходит verify-full
+	serverCert, serverKey := ca.Issue(t, testcert.Cert{CommonName: "localhost", DNSNames: []string{"localhost"}, Usage: x509.ExtKeyUsageServerAuth})
+	// для метода cert CN сертификата клиента должен совпадать с именем пользователя
+	clientCert, clientKey := ca.Issue(t, testcert.Cert{CommonName: testUser, Usage: x509.ExtKeyUsageClientAuth})
+	foreignCert, foreignKey := otherCA.Issue(t, testcert.Cert{CommonName: testUser, Usage: x509.ExtKeyUsageClientAuth})
+
+	files := map[string][]byte{
+		"ca.crt":     ca.PEM,
+		"client.crt": clientCert,
+		"client.key": clientKey,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	port := startPostgres(t, filepath.Join(dir, "data"), ca.PEM, serverCert, serverKey)
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "verify-full with certificate files",
+			config: Config{
+				Host: "localhost", SSLMode: SSLModeVerifyFull,
+				SSLRootCert: filepath.Join(dir, "ca.crt"),
+				SSLCert:     filepath.Join(dir, "client.crt"),
+				SSLKey:      filepath.Join(dir, "client.key"),
+			},
+		},
+		{
+			name: "verify-full with inline certificates",
+			config: Config{
+				Host: "localhost", SSLMode: SSLModeVerifyFull,
+				SSLRootCertPEM: string(ca.PEM), SSLCertPEM: string(clientCert), SSLKeyPEM: string(clientKey),
+			},
+		},
+		{
+			name: "verify-full rejects host missing from certificate",
+			config: Config{
+				Host: "127.0.0.1", SSLMode: SSLModeVerifyFull,
+				SSLRootCertPEM: string(ca.PEM), SSLCertPEM: string(clientCert), SSLKeyPEM: string(clientKey),
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify-ca ignores host name",
+			config: Config{
+				Host: "127.0.0.1", SSLMode: SSLModeVerifyCA,
+				SSLRootCertPEM: string(ca.PEM), SSLCertPEM: string(clientCert), SSLKeyPEM: string(clientKey),
+			},
+		},
+		{
+			name: "verify-ca rejects server of another CA",
+			config: Config{
+				Host: "127.0.0.1", SSLMode: SSLModeVerifyCA,
+				SSLRootCertPEM: string(otherCA.PEM), SSLCertPEM: string(clientCert), SSLKeyPEM: string(clientKey),
+			},
+			wantErr: true,
+		},
+		{
+			name: "require with root certificate verifies chain",
+			config: Config{
+				Host: "127.0.0.1", SSLMode: SSLModeRequire,
+				SSLRootCertPEM: string(otherCA.PEM), SSLCertPEM: string(clientCert), SSLKeyPEM: string(clientKey),
+			},
+			wantErr: true,
+		},
+		{
+			name: "require without root certificate",
+			config: Config{
+				Host: "127.0.0.1", SSLMode: SSLModeRequire,
+				SSLCertPEM: string(clientCert), SSLKeyPEM: string(clientKey),
+			},
+		},
+		{
+			name: "server rejects connection without client certificate",
+			config: Config{
+				Host: "localhost", SSLMode: SSLModeVerifyFull,
+				SSLRootCertPEM: string(ca.PEM),
+			},
+			wantErr: true,
+		},
+		{
+			name: "server rejects client certificate of another CA",
+			config: Config{
+				Host: "localhost", SSLMode: SSLModeVerifyFull,
+				SSLRootCertPEM: string(ca.PEM), SSLCertPEM: string(foreignCert), SSLKeyPEM: string(foreignKey),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "server rejects plain connection",
+			config:  Config{Host: "127.0.0.1", SSLMode: SSLModeDisabled},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.Port = port
+			config.DBName = "postgres"
+			config.User = testUser
+			// метод cert не проверяет пароль, но DSNProvider требует его
+			config.Password = "unused"
+			config.ConnectTimeout = 5
+
+			db, err := Connect(config, UserRoleFullAccess, nil)
+			if err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			defer db.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err = db.PingContext(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ping() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+const testUser = "rollstory"
+
+// startPostgres инициализирует кластер в dataDir, включает TLS с сертификатом клиента
+// и возвращает порт. Кластер останавливается по завершении теста.
+func startPostgres(t *testing.T, dataDir string, caPEM, certPEM, keyPEM []byte) int {
+	t.Helper()
+
+	if os.Geteuid() == 0 {
+		t.Skip("initdb refuses to run as root")
+	}
+
+	initdb, pgCtl := pgBinary(t, "initdb"), pgBinary(t, "pg_ctl")
+
+	run(t, initdb, "-D", dataDir, "-U", testUser, "--auth=trust", "--no-sync")
+
+	for name, content := range map[string][]byte{"root.crt": caPEM, "server.crt": certPEM, "server.key": keyPEM} {
+		if err := os.WriteFile(filepath.Join(dataDir, name), content, 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	hba := "hostssl all all 127.0.0.1/32 cert\nhostssl all all ::1/128 cert\n"
+	if err := os.WriteFile(filepath.Join(dataDir, "pg_hba.conf"), []byte(hba), 0o600); err != nil {
+		t.Fatalf("write pg_hba.conf: %v", err)
+	}
+
+	port := freePort(t)
+	conf := fmt.Sprintf(
+		"port = %d\nlisten_addresses = 'localhost'\nunix_socket_directories = '%s'\n"+
+			"ssl = on\nssl_cert_file = 'server.crt'\nssl_key_file = 'server.key'\nssl_ca_file = 'root.crt'\n",
+		port, dataDir,
+	)
+
+	f, err := os.OpenFile(filepath.Join(dataDir, "postgresql.conf"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open postgresql.conf: %v", err)
+	}
+	if _, err := f.WriteString(conf); err != nil {
+		t.Fatalf("write postgresql.conf: %v", err)
+	}
+	_ = f.Close()
+
+	run(t, pgCtl, "-D", dataDir, "-l", filepath.Join(dataDir, "server.log"), "-w", "start")
+	t.Cleanup(func() {
+		_ = exec.Command(pgCtl, "-D", dataDir, "-m", "immediate", "stop").Run()
+	})
+
+	return port
+}
+
+func pgBinary(t *testing.T, name string) string {
+	t.Helper()
+
+	if dir := os.Getenv("PG_BIN_DIR"); dir != "" {
+		return filepath.Join(dir, name)
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		t.Skipf("%s not found in PATH, set PG_BIN_DIR to the Postgres bin directory", name)
+	}
+
+	return path
+}
+
+func run(t *testing.T, name string, args ...string) {
+	t.Helper()
+
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		t.Fatalf("%s: %v\n%s", filepath.Base(name), err, out)
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	return ln.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	defaultTxMaxRetries = 3
+	defaultTxRetryDelay = 50 * time.Millisecond
+)
+
+type txKey struct{}
+
+// txState хранится в контексте на время транзакции
+type txState struct {
+	tx         *sqlx.Tx
+	savepoints int
+}
+
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	isolation  sql.IsolationLevel
+	readOnly   bool
+	maxRetries int
+	retryDelay time.Duration
+}
+
+// WithIsolationLevel задает уровень изоляции транзакции
+func WithIsolationLevel(level sql.IsolationLevel) TxOption {
+	return func(o *txOptions) {
+		o.isolation = level
+	}
+}
+
+// WithReadOnly открывает транзакцию только на чтение
+func WithReadOnly() TxOption {
+	return func(o *txOptions) {
+		o.readOnly = true
+	}
+}
+
+// WithMaxRetries задает количество повторов транзакции при ошибках сериализации и дедлоках,
+// 0 отключает повторы
+func WithMaxRetries(retries int) TxOption {
+	return func(o *txOptions) {
+		o.maxRetries = retries
+	}
+}
+
+// WithRetryDelay задает базовую задержку между повторами, с каждым повтором она удваивается
+func WithRetryDelay(delay time.Duration) TxOption {
+	return func(o *txOptions) {
+		o.retryDelay = delay
+	}
+}
+
+// TxManager выполняет функции внутри транзакции. Транзакция кладется в контекст,
+// поэтому все запросы через соединения, учитывающие TxFromContext, автоматически в ней участвуют.
+type TxManager struct {
+	db   *sqlx.DB
+	opts []TxOption
+}
+
+// NewTxManager создает TxManager, opts применяются ко всем транзакциям по умолчанию
+func NewTxManager(db *sqlx.DB, opts ...TxOption) *TxManager {
+	return &TxManager{
+		db:   db,
+		opts: opts,
+	}
+}
+
+// TxFromContext возвращает транзакцию, открытую TxManager выше по стеку вызовов
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+
+	return state.tx, true
+}
+
+// Do выполняет fn в транзакции и фиксирует ее, если fn не вернула ошибку.
+//
+// Если в ctx уже есть транзакция, fn выполняется внутри SAVEPOINT: ошибка откатывает
+// только изменения fn, а параметры изоляции и повторы берутся от внешней транзакции.
+// Внешняя транзакция повторяется целиком при ошибках 40001 и 40P01, поэтому fn
+// должна быть идемпотентной относительно всего, кроме базы данных.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return m.doSavepoint(ctx, state, fn)
+	}
+
+	o := txOptions{
+		isolation:  sql.LevelDefault,
+		maxRetries: defaultTxMaxRetries,
+		retryDelay: defaultTxRetryDelay,
+	}
+
+	for _, opt := range m.opts {
+		opt(&o)
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	delay := o.retryDelay
+	for attempt := 0; ; attempt++ {
+		err := m.doTx(ctx, o, fn)
+		if err == nil || attempt >= o.maxRetries || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+func (m *TxManager) doTx(ctx context.Context, o txOptions, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: o.isolation,
+		ReadOnly:  o.readOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (m *TxManager) doSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) (err error) {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if _, rbErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback to savepoint: %w", rbErr))
+		}
+
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// isRetryable сообщает, можно ли безопасно повторить транзакцию целиком
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == codeSerializationFailure || pgErr.Code == codeDeadlockDetected
+}
@@ -5,18 +5,38 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jmoiron/sqlx"
 	"strings"
+	"time"
 )
 
 const (
 	dsnSchemePostgreSQL = "postgresql"
+	// драйвер, регистрируемый github.com/jackc/pgx/v4/stdlib
+	defaultDriverName = "pgx"
 )
 
+// SSLModeType - режим sslmode в терминах libpq
 type SSLModeType string
 
 const (
-	SSLModeDisabled SSLModeType = "disabled"
+	SSLModeDisabled   SSLModeType = "disable"
+	SSLModeAllow      SSLModeType = "allow"
+	SSLModePrefer     SSLModeType = "prefer"
+	SSLModeRequire    SSLModeType = "require"
+	SSLModeVerifyCA   SSLModeType = "verify-ca"
+	SSLModeVerifyFull SSLModeType = "verify-full"
 )
 
+// UnmarshalText позволяет валидировать sslmode при загрузке конфигурации
+func (m *SSLModeType) UnmarshalText(text []byte) error {
+	mode, err := ParseSSLMode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*m = mode
+	return nil
+}
+
 type UserRole int
 
 const (
@@ -25,26 +45,48 @@ const (
 	UserRoleFullAccess
 )
 
+func (r UserRole) String() string {
+	switch r {
+	case UserRoleReadOnly:
+		return "read-only"
+	case UserRoleReadWrite:
+		return "read-write"
+	case UserRoleFullAccess:
+		return "full-access"
+	default:
+		return fmt.Sprintf("UserRole(%d)", int(r))
+	}
+}
+
 type Connection struct {
 	sqlx.DB
 }
 
 type Options struct {
-	host           string
-	port           int
-	user           string
-	password       string
-	dbName         string
-	dsnName        string
-	driverName     string
-	connectTimeout int
-	sslMode        SSLModeType
-	userRole       UserRole
-	LogLevel       pgx.LogLevel
-	Logger         pgx.Logger
+	host            string
+	port            int
+	user            string
+	password        string
+	dbName          string
+	dsnName         string
+	driverName      string
+	connectTimeout  int
+	sslMode         SSLModeType
+	sslRootCert     []byte
+	sslCert         []byte
+	sslKey          []byte
+	userRole        UserRole
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	LogLevel        pgx.LogLevel
+	Logger          pgx.Logger
 }
 
 type databaseUsers struct {
+	ReadOnly   *databaseUser `json:"readOnly"`
+	ReadWrite  *databaseUser `json:"readWrite"`
 	FullAccess *databaseUser `json:"fullAccess"`
 }
 
@@ -53,23 +95,71 @@ type databaseUser struct {
 	Password string `json:"password"`
 }
 
-// env ключ для каждого нужного значения для подключения ДБ
-type databaseENV struct {
-	Host           string
-	Port           string
-	DbName         string
-	User           string
-	Password       string
-	SSLMode        string
-	ConnectTimeout string
+// Config описывает параметры подключения к БД, заполняется через env.Load.
+// Ключи указаны без префикса, по умолчанию используется префикс PG_.
+type Config struct {
+	Host           string      `env:"HOST" required:"true"`
+	Port           int         `env:"PORT" required:"true"`
+	DBName         string      `env:"DBNAME" required:"true"`
+	User           string      `env:"USER" required:"true"`
+	Password       string      `env:"PASSWORD" required:"true"`
+	SSLMode        SSLModeType `env:"SSLMODE" default:"disable"`
+	ConnectTimeout int         `env:"CONNECT_TIMEOUT" default:"10"`
+
+	// Сертификаты задаются путем до файла или PEM содержимым, файл имеет приоритет.
+	// Ключ клиента не должен быть зашифрован.
+	SSLRootCert    string `env:"SSLROOTCERT"`
+	SSLRootCertPEM string `env:"SSLROOTCERT_PEM"`
+	SSLCert        string `env:"SSLCERT"`
+	SSLCertPEM     string `env:"SSLCERT_PEM"`
+	SSLKey         string `env:"SSLKEY"`
+	SSLKeyPEM      string `env:"SSLKEY_PEM"`
+
+	// Учетные данные пользователей с ограниченными правами. Если для роли они не заданы,
+	// используется пользователь следующей по правам роли (read-only -> read-write -> full access)
+	ReadOnlyUser      string `env:"RO_USER"`
+	ReadOnlyPassword  string `env:"RO_PASSWORD"`
+	ReadWriteUser     string `env:"RW_USER"`
+	ReadWritePassword string `env:"RW_PASSWORD"`
+
+	// Логирование запросов через pgx: уровень pgx и порог медленного запроса.
+	// Медленные запросы логируются с уровнем warning при любом LogLevel, включая none.
+	LogLevel           string        `env:"LOG_LEVEL" default:"info" oneof:"trace,debug,info,warn,error,none"`
+	SlowQueryThreshold time.Duration `env:"SLOW_QUERY_THRESHOLD" default:"500ms"`
+
+	// Ожидание доступности БД при старте
+	ConnectAttempts     int           `env:"CONNECT_ATTEMPTS" default:"10"`
+	ConnectInitialDelay time.Duration `env:"CONNECT_INITIAL_DELAY" default:"500ms"`
+	ConnectMaxDelay     time.Duration `env:"CONNECT_MAX_DELAY" default:"10s"`
+	ConnectJitter       float64       `env:"CONNECT_JITTER" default:"0.2"`
+
+	// Настройки пула соединений database/sql. Нулевые MaxOpenConns и длительности
+	// снимают ограничение, нулевой MaxIdleConns отключает простаивающие соединения
+	MaxOpenConns    int           `env:"MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `env:"MAX_IDLE_CONNS" default:"25"`
+	ConnMaxLifetime time.Duration `env:"CONN_MAX_LIFETIME" default:"30m"`
+	ConnMaxIdleTime time.Duration `env:"CONN_MAX_IDLE_TIME" default:"5m"`
+
+	// Фоновая проверка доступности БД
+	HealthInterval time.Duration `env:"HEALTH_INTERVAL" default:"15s"`
+	HealthTimeout  time.Duration `env:"HEALTH_TIMEOUT" default:"2s"`
+
+	// Реплика, на которую направляется пул read-only роли
+	ReplicaHost string `env:"REPLICA_HOST"`
+	ReplicaPort int    `env:"REPLICA_PORT"`
 }
 
 type databaseDSN struct {
 	Name           string        `json:"name"`
 	Host           string        `json:"host"`
 	Port           int           `json:"port"`
+	ReplicaHost    string        `json:"replica_host"`
+	ReplicaPort    int           `json:"replica_port"`
 	DbName         string        `json:"dbname"`
 	SSLMode        string        `json:"sslmode"`
+	SSLRootCert    []byte        `json:"sslrootcert"`
+	SSLCert        []byte        `json:"sslcert"`
+	SSLKey         []byte        `json:"sslkey"`
 	ConnectTimeout int           `json:"connect_timeout"`
 	User           databaseUsers `json:"user"`
 }
@@ -84,13 +174,20 @@ type DSN struct {
 	UserLogin      string
 	UserPassword   string
 	SSLMode        string
-	// добавить сохранение сертификата
+	// PEM содержимое сертификатов
+	SSLRootCert []byte
+	SSLCert     []byte
+	SSLKey      []byte
 }
 
-// unused
 func ParseSSLMode(mode string) (SSLModeType, error) {
 	modes := []string{
 		string(SSLModeDisabled),
+		string(SSLModeAllow),
+		string(SSLModePrefer),
+		string(SSLModeRequire),
+		string(SSLModeVerifyCA),
+		string(SSLModeVerifyFull),
 	}
 
 	for _, m := range modes {
@@ -0,0 +1,423 @@
+package env
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Поддерживаемые теги структуры конфигурации:
+//
+//	env:"PORT"            ключ параметра (одинаковый для окружения, файла и флагов)
+//	default:"8080"        значение по умолчанию
+//	required:"true"       параметр обязателен
+//	oneof:"json,text"     допустимые значения (без учета регистра)
+//	flag:"port"           имя флага командной строки, "-" отключает флаг
+//	prefix:"PG_"          префикс ключей вложенной структуры
+const (
+	tagEnv      = "env"
+	tagDefault  = "default"
+	tagRequired = "required"
+	tagOneOf    = "oneof"
+	tagFlag     = "flag"
+	tagPrefix   = "prefix"
+)
+
+// ConfigFlag - имя флага командной строки с путем до файла конфигурации
+const ConfigFlag = "config"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// FieldError описывает ошибку заполнения одного параметра конфигурации
+type FieldError struct {
+	Key string
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// LoadOption настраивает источники, из которых Load заполняет конфигурацию
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	prefix   string
+	file     string
+	args     []string
+	withArgs bool
+	lookup   func(string) (string, bool)
+}
+
+// WithPrefix добавляет префикс ко всем ключам конфигурации
+func WithPrefix(prefix string) LoadOption {
+	return func(o *loadOptions) {
+		o.prefix = prefix
+	}
+}
+
+// WithFile задает YAML или JSON файл с конфигурацией. Пустой путь игнорируется.
+func WithFile(path string) LoadOption {
+	return func(o *loadOptions) {
+		o.file = path
+	}
+}
+
+// WithArgs включает чтение флагов командной строки. Помимо флагов для каждого параметра
+// доступен флаг -config с путем до файла конфигурации, он имеет приоритет над WithFile.
+func WithArgs(args []string) LoadOption {
+	return func(o *loadOptions) {
+		o.args = args
+		o.withArgs = true
+	}
+}
+
+// WithLookup подменяет функцию чтения переменных окружения (по умолчанию os.LookupEnv)
+func WithLookup(lookup func(string) (string, bool)) LoadOption {
+	return func(o *loadOptions) {
+		o.lookup = lookup
+	}
+}
+
+// Load заполняет структуру dst значениями из источников в порядке возрастания приоритета:
+// значение по умолчанию, файл конфигурации, переменная окружения, флаг командной строки.
+// Пустые значения считаются отсутствующими.
+//
+// Load не останавливается на первой ошибке: в результате возвращаются все невалидные
+// и незаполненные обязательные ключи, каждый в виде *FieldError.
+func Load(dst any, opts ...LoadOption) error {
+	o := loadOptions{
+		lookup: os.LookupEnv,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config destination must be a non-nil pointer to struct, got %T", dst)
+	}
+
+	fields, err := collectFields(v.Elem(), o.prefix)
+	if err != nil {
+		return err
+	}
+
+	flags, err := parseFlags(fields, &o)
+	if err != nil {
+		return err
+	}
+
+	file, err := readFile(o.file)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+	for _, f := range fields {
+		raw, ok := f.defaultValue, f.defaultValue != ""
+
+		if val, found := file[strings.ToUpper(f.key)]; found && val != "" {
+			raw, ok = val, true
+		}
+
+		if val, found := o.lookup(f.key); found && val != "" {
+			raw, ok = val, true
+		}
+
+		if val, found := flags[f.key]; found && val != "" {
+			raw, ok = val, true
+		}
+
+		if !ok {
+			if f.required {
+				errs = append(errs, &FieldError{Key: f.key, Err: errors.New("required but not set")})
+			}
+			continue
+		}
+
+		if len(f.oneOf) > 0 && !containsFold(f.oneOf, raw) {
+			errs = append(errs, &FieldError{
+				Key: f.key,
+				Err: fmt.Errorf("value %q is not one of: %s", raw, strings.Join(f.oneOf, ", ")),
+			})
+			continue
+		}
+
+		if err := setValue(f.value, raw); err != nil {
+			errs = append(errs, &FieldError{Key: f.key, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type configField struct {
+	key          string
+	flag         string
+	defaultValue string
+	required     bool
+	oneOf        []string
+	value        reflect.Value
+}
+
+func collectFields(v reflect.Value, prefix string) ([]configField, error) {
+	t := v.Type()
+	fields := make([]configField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		key, hasKey := sf.Tag.Lookup(tagEnv)
+
+		// вложенные структуры без ключа разворачиваются с учетом префикса
+		if !hasKey && sf.Type.Kind() == reflect.Struct && !isLeafType(sf.Type) {
+			nested, err := collectFields(fv, prefix+sf.Tag.Get(tagPrefix))
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, nested...)
+			continue
+		}
+
+		if !hasKey || key == "-" {
+			continue
+		}
+
+		if !isSupportedType(sf.Type) {
+			return nil, fmt.Errorf("config field %s has unsupported type %s", sf.Name, sf.Type)
+		}
+
+		f := configField{
+			key:          prefix + key,
+			defaultValue: sf.Tag.Get(tagDefault),
+			value:        fv,
+		}
+
+		if required, err := strconv.ParseBool(sf.Tag.Get(tagRequired)); err == nil {
+			f.required = required
+		}
+
+		if oneOf := sf.Tag.Get(tagOneOf); oneOf != "" {
+			f.oneOf = splitList(oneOf)
+		}
+
+		f.flag = sf.Tag.Get(tagFlag)
+		if f.flag == "" {
+			f.flag = strings.ReplaceAll(strings.ToLower(f.key), "_", "-")
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// parseFlags возвращает значения заданных в командной строке флагов по ключу параметра
+func parseFlags(fields []configField, o *loadOptions) (map[string]string, error) {
+	values := make(map[string]string)
+	if !o.withArgs {
+		return values, nil
+	}
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	configFile := fs.String(ConfigFlag, "", "path to YAML or JSON config file")
+
+	keys := make(map[string]string, len(fields))
+	for _, f := range fields {
+		if f.flag == "-" {
+			continue
+		}
+
+		if fs.Lookup(f.flag) != nil {
+			return nil, fmt.Errorf("duplicate config flag -%s", f.flag)
+		}
+
+		fs.String(f.flag, "", f.key)
+		keys[f.flag] = f.key
+	}
+
+	if err := fs.Parse(o.args); err != nil {
+		return nil, fmt.Errorf("parse command line flags: %w", err)
+	}
+
+	// учитываем только явно переданные флаги
+	fs.Visit(func(fl *flag.Flag) {
+		if key, ok := keys[fl.Name]; ok {
+			values[key] = fl.Value.String()
+		}
+	})
+
+	if *configFile != "" {
+		o.file = *configFile
+	}
+
+	return values, nil
+}
+
+func isLeafType(t reflect.Type) bool {
+	return t == urlType || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func isSupportedType(t reflect.Type) bool {
+	if t == durationType || isLeafType(t) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		// вложенные срезы не поддерживаются, кроме типов со своим UnmarshalText
+		return isSupportedType(t.Elem()) && (t.Elem().Kind() != reflect.Slice || isLeafType(t.Elem()))
+	case reflect.Ptr:
+		return isSupportedType(t.Elem())
+	default:
+		return false
+	}
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), raw); err != nil {
+			return err
+		}
+
+		v.Set(ptr)
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch {
+	case v.Type() == durationType:
+		d, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	case v.Type() == urlType:
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+
+		v.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("value %q must be a boolean", raw)
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("value %q must be an integer", raw)
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("value %q must be an unsigned integer", raw)
+		}
+
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("value %q must be a number", raw)
+		}
+
+		v.SetFloat(f)
+	case reflect.Slice:
+		items := splitList(raw)
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i := range items {
+			if err := setValue(slice.Index(i), items[i]); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// parseDuration дополнительно к формату time.ParseDuration принимает целое число секунд
+func parseDuration(raw string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("value %q must be a duration (e.g. 5s, 1m) or number of seconds", raw)
+	}
+
+	return d, nil
+}
+
+func splitList(raw string) []string {
+	res := make([]string, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readFile читает файл конфигурации и приводит его к плоскому виду: ключи вложенных
+// объектов склеиваются через "_" и переводятся в верхний регистр, поэтому
+//
+//	listener:
+//	  read_timeout: 5s
+//
+// соответствует ключу LISTENER_READ_TIMEOUT. Списки склеиваются через запятую.
+func readFile(path string) (map[string]string, error) {
+	values := make(map[string]string)
+	if path == "" {
+		return values, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var data map[string]any
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &data)
+	case ".json":
+		err = json.Unmarshal(content, &data)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, use .yaml, .yml or .json", ext)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	flatten("", data, values)
+
+	return values, nil
+}
+
+func flatten(prefix string, data map[string]any, dst map[string]string) {
+	for k, v := range data {
+		key := strings.ToUpper(k)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch tv := v.(type) {
+		case map[string]any:
+			flatten(key, tv, dst)
+		case []any:
+			items := make([]string, 0, len(tv))
+			for _, item := range tv {
+				items = append(items, fmt.Sprint(item))
+			}
+			dst[key] = strings.Join(items, ",")
+		case nil:
+			dst[key] = ""
+		default:
+			dst[key] = fmt.Sprint(tv)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package health
+
+import "time"
+
+// Config описывает настройки проверок состояния, заполняется через env.Load
+type Config struct {
+	CheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	DiskPath     string        `env:"HEALTH_DISK_PATH" default:"/"`
+	DiskMinFree  uint64        `env:"HEALTH_DISK_MIN_FREE_MB" default:"512"`
+}
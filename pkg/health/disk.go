@@ -0,0 +1,24 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+const megabyte = 1 << 20
+
+// DiskSpace проверяет, что на разделе с path свободно не меньше minFreeMB мегабайт
+func DiskSpace(path string, minFreeMB uint64) CheckFunc {
+	return func(_ context.Context) error {
+		free, err := freeSpace(path)
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+
+		if free < minFreeMB*megabyte {
+			return fmt.Errorf("low disk space on %s: %d MB free, %d MB required", path, free/megabyte, minFreeMB)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Ответы повторяют схемы HealthStatus и HealthReport из api/schema.yaml, чтобы пробы
+// на публичном и служебном портах были взаимозаменяемы
+
+type statusResponse struct {
+	Status Status `json:"status"`
+}
+
+type checkResponse struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type reportResponse struct {
+	Status Status          `json:"status"`
+	Checks []checkResponse `json:"checks"`
+}
+
+// LiveHandler - HTTP обработчик liveness пробы
+func (r *Registry) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_ = WriteStatus(w, r.Live(req.Context()))
+	})
+}
+
+// ReadyHandler - HTTP обработчик readiness пробы, отвечает 503 при статусе fail
+func (r *Registry) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_ = WriteStatus(w, r.Ready(req.Context()))
+	})
+}
+
+// ReportHandler - HTTP обработчик подробного отчета с текстами ошибок проверок,
+// отвечает 503 при статусе fail. Предназначен для служебного порта.
+func (r *Registry) ReportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_ = WriteReport(w, r.Run(req.Context()), true)
+	})
+}
+
+// WriteStatus записывает статус пробы, при статусе fail отвечает 503
+func WriteStatus(w http.ResponseWriter, status Status) error {
+	return writeJSON(w, statusCode(status), statusResponse{Status: status})
+}
+
+// WriteReport записывает отчет о проверках, при статусе fail отвечает 503. Тексты ошибок
+// раскрывают адреса и устройство зависимостей, поэтому details включается только там,
+// куда нет доступа извне.
+func WriteReport(w http.ResponseWriter, report Report, details bool) error {
+	resp := reportResponse{
+		Status: report.Status,
+		Checks: make([]checkResponse, 0, len(report.Checks)),
+	}
+
+	for _, res := range report.Checks {
+		check := checkResponse{
+			Name:       res.Name,
+			Status:     res.Status,
+			Critical:   res.Critical,
+			DurationMs: res.Duration.Milliseconds(),
+		}
+
+		if details && res.Err != nil {
+			check.Error = res.Err.Error()
+		}
+
+		resp.Checks = append(resp.Checks, check)
+	}
+
+	return writeJSON(w, statusCode(report.Status), resp)
+}
+
+func statusCode(status Status) int {
+	if status == StatusFail {
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusOK
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	return json.NewEncoder(w).Encode(v)
+}
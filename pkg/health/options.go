@@ -0,0 +1,33 @@
+package health
+
+import "time"
+
+type CheckOption func(*check)
+
+// WithTimeout задает таймаут проверки, по умолчанию используется таймаут реестра
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(c *check) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithCritical помечает проверку критичной: ее падение переводит сервис в fail
+// и снимает его с балансировки. Некритичные проверки дают только degraded.
+func WithCritical(critical bool) CheckOption {
+	return func(c *check) {
+		c.critical = critical
+	}
+}
+
+type Option func(*Registry)
+
+// WithDefaultTimeout задает таймаут для проверок без WithTimeout
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(r *Registry) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
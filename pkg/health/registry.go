@@ -0,0 +1,210 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultCheckTimeout = 2 * time.Second
+
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusFail     Status = "fail"
+)
+
+var ErrShuttingDown = errors.New("service is shutting down")
+
+// CheckFunc проверяет зависимость, nil означает, что зависимость доступна
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	fn       CheckFunc
+	timeout  time.Duration
+	critical bool
+}
+
+// CheckResult - результат одной проверки
+type CheckResult struct {
+	Name     string
+	Status   Status
+	Critical bool
+	Duration time.Duration
+	Err      error
+}
+
+// Report - результат всех проверок, Checks отсортированы по имени
+type Report struct {
+	Status Status
+	Checks []CheckResult
+}
+
+// Registry хранит проверки зависимостей. Каждая зависимость регистрирует свою проверку,
+// а liveness, readiness и подробный отчет строятся из них.
+type Registry struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks []check
+
+	shuttingDown atomic.Bool
+}
+
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{
+		timeout: defaultCheckTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Register добавляет проверку, по умолчанию критичную. Повторная регистрация имени заменяет проверку.
+func (r *Registry) Register(name string, fn CheckFunc, opts ...CheckOption) {
+	c := check{
+		name:     name,
+		fn:       fn,
+		timeout:  r.timeout,
+		critical: true,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.checks {
+		if r.checks[i].name == name {
+			r.checks[i] = c
+			return
+		}
+	}
+
+	r.checks = append(r.checks, c)
+}
+
+// SetShuttingDown переключает признак завершения работы. Пока он установлен, Ready
+// возвращает fail, чтобы балансировщик перестал направлять новые запросы.
+func (r *Registry) SetShuttingDown(shuttingDown bool) {
+	r.shuttingDown.Store(shuttingDown)
+}
+
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Live сообщает, что процесс жив. Зависимости не проверяются: их недоступность
+// не лечится перезапуском сервиса.
+func (r *Registry) Live(_ context.Context) Status {
+	return StatusOK
+}
+
+// Ready выполняет проверки и возвращает итоговый статус для readiness пробы
+func (r *Registry) Ready(ctx context.Context) Status {
+	if r.ShuttingDown() {
+		return StatusFail
+	}
+
+	return r.Run(ctx).Status
+}
+
+// Run параллельно выполняет все проверки и собирает отчет
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := make([]check, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, checks[i])
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	report := Report{
+		Status: StatusOK,
+		Checks: results,
+	}
+
+	for _, res := range results {
+		switch {
+		case res.Err == nil:
+		case res.Critical:
+			report.Status = StatusFail
+		case report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+
+	if r.ShuttingDown() {
+		report.Status = StatusFail
+	}
+
+	return report
+}
+
+func runCheck(ctx context.Context, c check) (res CheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res = CheckResult{
+		Name:     c.name,
+		Status:   StatusOK,
+		Critical: c.critical,
+	}
+
+	start := time.Now()
+	defer func() {
+		res.Duration = time.Since(start)
+
+		if res.Err != nil {
+			res.Status = StatusFail
+			if !c.critical {
+				res.Status = StatusDegraded
+			}
+		}
+	}()
+
+	// проверка может не уважать контекст, поэтому таймаут соблюдаем сами
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				errCh <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+
+		errCh <- c.fn(ctx)
+	}()
+
+	select {
+	case res.Err = <-errCh:
+	case <-ctx.Done():
+		res.Err = fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+
+	return res
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+)
+
+// New собирает обработчик служебного порта: пробы, метрики expvar, pprof и управление
+// уровнем логирования. Порт не должен быть доступен извне кластера.
+func New(opts ...Option) http.Handler {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	mux := http.NewServeMux()
+
+	if o.health != nil {
+		mux.Handle("GET /healthz", o.health.LiveHandler())
+		mux.Handle("GET /readyz", o.health.ReadyHandler())
+		mux.Handle("GET /health", o.health.ReportHandler())
+	}
+
+	for name, fn := range o.metrics {
+		// expvar.Publish паникует на повторное имя, а переменные глобальны для процесса
+		if expvar.Get(name) == nil {
+			expvar.Publish(name, expvar.Func(fn))
+		}
+	}
+	mux.Handle("GET /debug/vars", expvar.Handler())
+
+	if o.pprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	if o.level != nil {
+		mux.HandleFunc("GET /log/level", getLevel(o.level))
+		mux.HandleFunc("PUT /log/level", setLevel(o.level))
+	}
+
+	return mux
+}
+
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+func getLevel(level LevelController) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, levelPayload{Level: level.Level()})
+	}
+}
+
+func setLevel(level LevelController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload levelPayload
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&payload); err != nil {
+			http.Error(w, "invalid payload, expected {\"level\": \"DEBUG|INFO|WARN|ERROR\"}", http.StatusBadRequest)
+			return
+		}
+
+		if err := level.SetLevel(payload.Level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, levelPayload{Level: level.Level()})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,8 @@
+package admin
+
+// Config описывает служебный порт, заполняется через env.Load
+type Config struct {
+	// Port служебного сервера, 0 отключает его
+	Port  int  `env:"ADMIN_PORT" default:"8081"`
+	Pprof bool `env:"ADMIN_PPROF" default:"true"`
+}
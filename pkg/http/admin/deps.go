@@ -0,0 +1,16 @@
+package admin
+
+import "net/http"
+
+// LevelController меняет уровень логирования на лету, реализуется *logger.Logger
+type LevelController interface {
+	SetLevel(level string) error
+	Level() string
+}
+
+// HealthHandlers отдает обработчики проб, реализуется *health.Registry
+type HealthHandlers interface {
+	LiveHandler() http.Handler
+	ReadyHandler() http.Handler
+	ReportHandler() http.Handler
+}
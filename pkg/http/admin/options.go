@@ -0,0 +1,43 @@
+package admin
+
+type Option func(*options)
+
+type options struct {
+	health  HealthHandlers
+	level   LevelController
+	pprof   bool
+	metrics map[string]func() any
+}
+
+// WithHealth добавляет /healthz, /readyz и /health
+func WithHealth(health HealthHandlers) Option {
+	return func(o *options) {
+		o.health = health
+	}
+}
+
+// WithLogLevel добавляет /log/level: GET возвращает текущий уровень, PUT меняет его
+func WithLogLevel(level LevelController) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithPprof добавляет профилировщик /debug/pprof/
+func WithPprof(enabled bool) Option {
+	return func(o *options) {
+		o.pprof = enabled
+	}
+}
+
+// WithMetric публикует значение fn в /debug/vars под именем name. fn вызывается
+// при каждом запросе, результат сериализуется в JSON.
+func WithMetric(name string, fn func() any) Option {
+	return func(o *options) {
+		if o.metrics == nil {
+			o.metrics = make(map[string]func() any)
+		}
+
+		o.metrics[name] = fn
+	}
+}
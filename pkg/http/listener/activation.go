@@ -0,0 +1,180 @@
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Переменные окружения протокола socket activation systemd (sd_listen_fds) и
+// его расширение для передачи сокетов при обновлении бинарника, см. Group.UpgradeOn
+const (
+	envListenFDs        = "LISTEN_FDS"
+	envListenPID        = "LISTEN_PID"
+	envListenFDNames    = "LISTEN_FDNAMES"
+	envUpgradeParentPID = "LISTEN_UPGRADE_PPID"
+	envUpgradeReadyFD   = "LISTEN_UPGRADE_READY_FD"
+
+	// первый переданный дескриптор идет сразу после stdin, stdout и stderr
+	listenFDsStart = 3
+)
+
+// inheritedSet - сокеты, полученные от systemd или от предыдущего процесса
+type inheritedSet struct {
+	names     []string
+	listeners []net.Listener
+	claimed   []bool
+	ready     *os.File
+}
+
+var (
+	inheritOnce   sync.Once
+	inheritResult *inheritedSet
+	inheritErr    error
+)
+
+// inherited разбирает переменные окружения один раз за процесс и удаляет их,
+// чтобы дочерние процессы не приняли чужие дескрипторы за свои
+func inherited() (*inheritedSet, error) {
+	inheritOnce.Do(func() {
+		inheritResult, inheritErr = parseInherited()
+
+		for _, key := range []string{envListenFDs, envListenPID, envListenFDNames, envUpgradeParentPID, envUpgradeReadyFD} {
+			_ = os.Unsetenv(key)
+		}
+	})
+
+	return inheritResult, inheritErr
+}
+
+func parseInherited() (*inheritedSet, error) {
+	set := &inheritedSet{}
+
+	rawFDs := os.Getenv(envListenFDs)
+	if rawFDs == "" {
+		return set, nil
+	}
+
+	// сокеты предназначены этому процессу, только если совпадает pid (systemd)
+	// или pid родителя (обновление бинарника)
+	switch {
+	case os.Getenv(envListenPID) != "":
+		if os.Getenv(envListenPID) != strconv.Itoa(os.Getpid()) {
+			return set, nil
+		}
+	case os.Getenv(envUpgradeParentPID) != "":
+		if os.Getenv(envUpgradeParentPID) != strconv.Itoa(os.Getppid()) {
+			return set, nil
+		}
+	default:
+		return set, nil
+	}
+
+	count, err := strconv.Atoi(rawFDs)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid %s=%q", envListenFDs, rawFDs)
+	}
+
+	var names []string
+	if raw := os.Getenv(envListenFDNames); raw != "" {
+		names = strings.Split(raw, ":")
+	}
+
+	for i := 0; i < count; i++ {
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+
+		f := os.NewFile(uintptr(listenFDsStart+i), name)
+
+		// FileListener дублирует дескриптор, исходный больше не нужен
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			set.close()
+			return nil, fmt.Errorf("inherited fd %d: %w", listenFDsStart+i, err)
+		}
+
+		// файл сокета принадлежит тому, кто его создал
+		if unixLn, ok := ln.(*net.UnixListener); ok {
+			unixLn.SetUnlinkOnClose(false)
+		}
+
+		set.names = append(set.names, name)
+		set.listeners = append(set.listeners, ln)
+		set.claimed = append(set.claimed, false)
+	}
+
+	if raw := os.Getenv(envUpgradeReadyFD); raw != "" {
+		fd, err := strconv.Atoi(raw)
+		if err != nil {
+			set.close()
+			return nil, fmt.Errorf("invalid %s=%q", envUpgradeReadyFD, raw)
+		}
+
+		set.ready = os.NewFile(uintptr(fd), "upgrade-ready")
+	}
+
+	return set, nil
+}
+
+// take отдает сокет по имени, а если имена не заданы (systemd по умолчанию
+// называет их "unknown") - по порядковому номеру сервера
+func (s *inheritedSet) take(name string, index int) net.Listener {
+	if s == nil {
+		return nil
+	}
+
+	for i := range s.listeners {
+		if !s.claimed[i] && s.names[i] == name {
+			s.claimed[i] = true
+			return s.listeners[i]
+		}
+	}
+
+	if index < len(s.listeners) && !s.claimed[index] && (s.names[index] == "" || s.names[index] == "unknown") {
+		s.claimed[index] = true
+		return s.listeners[index]
+	}
+
+	return nil
+}
+
+// closeUnclaimed закрывает сокеты, которые не понадобились ни одному серверу
+func (s *inheritedSet) closeUnclaimed() []string {
+	if s == nil {
+		return nil
+	}
+
+	var names []string
+	for i := range s.listeners {
+		if !s.claimed[i] {
+			_ = s.listeners[i].Close()
+			names = append(names, s.names[i])
+		}
+	}
+
+	return names
+}
+
+func (s *inheritedSet) close() {
+	for _, ln := range s.listeners {
+		_ = ln.Close()
+	}
+}
+
+// notifyReady сообщает предыдущему процессу, что новый начал обслуживать запросы
+func (s *inheritedSet) notifyReady() error {
+	if s == nil || s.ready == nil {
+		return nil
+	}
+
+	_, err := s.ready.Write([]byte{1})
+
+	return errors.Join(err, s.ready.Close())
+}
@@ -0,0 +1,63 @@
+package listener
+
+import (
+	"fmt"
+	"io/fs"
+	"strconv"
+	"time"
+)
+
+// Config описывает настройки HTTP сервера, заполняется через env.Load
+type Config struct {
+	Port         int           `env:"PORT" default:"8080"`
+	ReadTimeout  time.Duration `env:"LISTENER_READ_TIMEOUT" default:"5s"`
+	WriteTimeout time.Duration `env:"LISTENER_WRITE_TIMEOUT" default:"5s"`
+	IdleTimeout  time.Duration `env:"LISTENER_IDLE_TIMEOUT" default:"5s"`
+	// ShutdownTimeout ограничивает ожидание завершения активных запросов при остановке
+	ShutdownTimeout time.Duration `env:"LISTENER_SHUTDOWN_TIMEOUT" default:"30s"`
+	// PreStopDelay - пауза между переключением readiness и остановкой приема соединений,
+	// за это время балансировщик успевает исключить инстанс
+	PreStopDelay time.Duration `env:"LISTENER_PRE_STOP_DELAY" default:"0s"`
+
+	// TLS включается, если задан TLSCertFile
+	TLSCertFile       string        `env:"LISTENER_TLS_CERT_FILE"`
+	TLSKeyFile        string        `env:"LISTENER_TLS_KEY_FILE"`
+	TLSClientCAFile   string        `env:"LISTENER_TLS_CLIENT_CA_FILE"`
+	TLSMinVersion     TLSVersion    `env:"LISTENER_TLS_MIN_VERSION" default:"1.2" oneof:"1.2,1.3"`
+	TLSCipherSuites   []string      `env:"LISTENER_TLS_CIPHER_SUITES"`
+	TLSReloadInterval time.Duration `env:"LISTENER_TLS_RELOAD_INTERVAL" default:"10s"`
+	// H2C включает HTTP/2 без TLS
+	H2C bool `env:"LISTENER_H2C" default:"false"`
+
+	// UnixSocket - путь к unix сокету, который слушается вместо PORT
+	UnixSocket     string     `env:"LISTENER_UNIX_SOCKET"`
+	UnixSocketMode SocketMode `env:"LISTENER_UNIX_SOCKET_MODE" default:"0660"`
+	// GracefulUpgrade включает обновление бинарника без разрыва соединений по SIGHUP
+	GracefulUpgrade bool `env:"LISTENER_GRACEFUL_UPGRADE" default:"false"`
+}
+
+// TLSVersion - версия TLS, из конфигурации разбирается из строк "1.2" и "1.3"
+type TLSVersion uint16
+
+func (v *TLSVersion) UnmarshalText(text []byte) error {
+	version, err := ParseTLSVersion(string(text))
+	if err != nil {
+		return err
+	}
+
+	*v = TLSVersion(version)
+	return nil
+}
+
+// SocketMode - права на файл unix сокета, из конфигурации разбираются в восьмеричной записи
+type SocketMode fs.FileMode
+
+func (m *SocketMode) UnmarshalText(text []byte) error {
+	mode, err := strconv.ParseUint(string(text), 8, 32)
+	if err != nil {
+		return fmt.Errorf("invalid socket mode %q: %w", text, err)
+	}
+
+	*m = SocketMode(mode)
+	return nil
+}
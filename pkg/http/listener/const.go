@@ -11,6 +11,7 @@ const (
 	defaultWriteTimeout    = 10 * time.Second
 	defaultIdleTimeout     = 60 * time.Second
 	defaultShutdownTimeout = 30 * time.Second
+	defaultHookTimeout     = 5 * time.Second
 )
 
 var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGINT, syscall.SIGTERM}
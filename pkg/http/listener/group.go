@@ -0,0 +1,187 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+type member struct {
+	name     string
+	listener *HTTPListener
+	port     int
+	handler  http.Handler
+}
+
+// Group запускает несколько серверов, например публичный API и служебный admin порт,
+// как единое целое: все порты открываются до начала обработки запросов, сигнал остановки
+// обрабатывается один раз, а ошибка или остановка любого сервера останавливает остальные.
+type Group struct {
+	logger  Logger
+	members []member
+	hooks   []shutdownHook
+
+	upgradeSignal  os.Signal
+	upgradeTimeout time.Duration
+}
+
+func NewGroup(logger Logger) *Group {
+	return &Group{
+		logger:         logger,
+		upgradeTimeout: defaultUpgradeTimeout,
+	}
+}
+
+// Add добавляет сервер в группу, name используется в логах и ошибках
+func (g *Group) Add(name string, listener *HTTPListener, port int, handler http.Handler) *Group {
+	g.members = append(g.members, member{
+		name:     name,
+		listener: listener,
+		port:     port,
+		handler:  handler,
+	})
+
+	return g
+}
+
+// OnShutdown регистрирует функцию, которая выполняется после остановки всех серверов группы,
+// см. WithOnShutdown. Общие ресурсы (пул БД, логгер) нужно закрывать здесь, а не в hooks
+// отдельных серверов, иначе они закроются, пока другие серверы еще обслуживают запросы.
+func (g *Group) OnShutdown(name string, timeout time.Duration, fn func(ctx context.Context) error) *Group {
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+
+	g.hooks = append(g.hooks, shutdownHook{
+		name:    name,
+		timeout: timeout,
+		fn:      fn,
+	})
+
+	return g
+}
+
+// UpgradeOn включает обновление без разрыва соединений: по сигналу sig процесс запускает
+// свой бинарник заново (обычно уже замененный новой версией) и передает ему сокеты,
+// а после того как новый процесс начал обслуживать запросы, завершается штатно.
+// Если новый процесс не стартовал за timeout (0 - defaultUpgradeTimeout), он убивается,
+// а текущий продолжает работу.
+func (g *Group) UpgradeOn(sig os.Signal, timeout time.Duration) *Group {
+	g.upgradeSignal = sig
+	if timeout > 0 {
+		g.upgradeTimeout = timeout
+	}
+
+	return g
+}
+
+// Listen открывает порты всех серверов и обслуживает запросы до сигнала остановки,
+// отмены ctx или завершения любого из серверов. Сокеты, переданные systemd (socket activation)
+// или предыдущим процессом при обновлении, используются вместо открытия новых:
+// сопоставление идет по имени сервера в LISTEN_FDNAMES, без имен - по порядку добавления.
+func (g *Group) Listen(ctx context.Context) error {
+	sockets, err := inherited()
+	if err != nil {
+		return errors.Join(err, runHooks(g.hooks))
+	}
+
+	bound := make([]*boundServer, 0, len(g.members))
+
+	for i, m := range g.members {
+		b, err := m.listener.bind(m.port, m.handler, sockets.take(m.name, i))
+		if err != nil {
+			for _, b := range bound {
+				_ = b.ln.Close()
+			}
+			sockets.closeUnclaimed()
+
+			return errors.Join(fmt.Errorf("%s listener: %w", m.name, err), runHooks(g.hooks))
+		}
+
+		bound = append(bound, b)
+	}
+
+	if unused := sockets.closeUnclaimed(); len(unused) > 0 && g.logger != nil {
+		g.logger.Warning(ctx, fmt.Sprintf("closed inherited sockets not matching any listener: %v", unused))
+	}
+
+	ctx, stop := notifyShutdown(ctx)
+	defer stop()
+
+	errs := make([]error, len(g.members))
+
+	var wg sync.WaitGroup
+	for i, m := range g.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// первый остановившийся сервер останавливает всю группу
+			defer stop()
+
+			if g.logger != nil {
+				g.logger.Info(ctx, fmt.Sprintf("%s listener serving on %s", m.name, bound[i].ln.Addr()))
+			}
+
+			if err := m.listener.serve(ctx, bound[i]); err != nil {
+				errs[i] = fmt.Errorf("%s listener: %w", m.name, err)
+			}
+		}()
+	}
+
+	if err := sockets.notifyReady(); err != nil && g.logger != nil {
+		g.logger.Error(ctx, "failed to notify previous process about readiness", err)
+	}
+
+	if g.upgradeSignal != nil {
+		go g.watchUpgrade(ctx, bound, stop)
+	}
+
+	wg.Wait()
+
+	return errors.Join(append(errs, runHooks(g.hooks))...)
+}
+
+// watchUpgrade выполняет обновление по сигналу и останавливает группу после успешной передачи сокетов
+func (g *Group) watchUpgrade(ctx context.Context, bound []*boundServer, stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, g.upgradeSignal)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+		}
+
+		g.info(ctx, "starting graceful upgrade")
+
+		if err := g.upgrade(bound); err != nil {
+			if g.logger != nil {
+				g.logger.Error(ctx, "graceful upgrade failed, continuing to serve", err)
+			}
+
+			continue
+		}
+
+		g.info(ctx, "new process is ready, handing over and shutting down")
+
+		for _, b := range bound {
+			b.handOver()
+		}
+		stop()
+
+		return
+	}
+}
+
+func (g *Group) info(ctx context.Context, msg string) {
+	if g.logger != nil {
+		g.logger.Info(ctx, msg)
+	}
+}
@@ -0,0 +1,83 @@
+package listener
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// activeRequest - запрос, обработка которого еще не завершилась
+type activeRequest struct {
+	Method     string
+	Path       string
+	RemoteAddr string
+	StartedAt  time.Time
+}
+
+// inflight отслеживает активные запросы, чтобы при остановке сообщить о прерванных
+type inflight struct {
+	mu       sync.Mutex
+	nextID   uint64
+	requests map[uint64]activeRequest
+}
+
+func newInflight() *inflight {
+	return &inflight{
+		requests: make(map[uint64]activeRequest),
+	}
+}
+
+func (f *inflight) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := f.add(activeRequest{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			RemoteAddr: r.RemoteAddr,
+			StartedAt:  time.Now(),
+		})
+		defer f.remove(id)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f *inflight) add(req activeRequest) uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	f.requests[f.nextID] = req
+
+	return f.nextID
+}
+
+func (f *inflight) remove(id uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.requests, id)
+}
+
+func (f *inflight) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.requests)
+}
+
+// snapshot возвращает активные запросы, самые долгие первыми
+func (f *inflight) snapshot() []activeRequest {
+	f.mu.Lock()
+	requests := make([]activeRequest, 0, len(f.requests))
+	for _, req := range f.requests {
+		requests = append(requests, req)
+	}
+	f.mu.Unlock()
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].StartedAt.Before(requests[j].StartedAt)
+	})
+
+	return requests
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,12 +33,52 @@ func New(opts ...Option) *HTTPListener {
 	}
 }
 
+type shutdownHook struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) error
+}
+
+// boundServer - сервер с уже открытым сокетом, готовый к запуску
+type boundServer struct {
+	server   *http.Server
+	ln       net.Listener
+	active   *inflight
+	reloader *certReloader
+
+	handedOver atomic.Bool
+}
+
 func (l *HTTPListener) Listen(ctx context.Context, port int, handler http.Handler) error {
+	b, err := l.bind(port, handler, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := notifyShutdown(ctx)
+	defer stop()
+
+	return l.serve(ctx, b)
+}
+
+// bind собирает сервер и открывает сокет, но не начинает принимать соединения.
+// Так группа может убедиться, что все порты свободны, до запуска любого из серверов.
+// Сокет выбирается в порядке: унаследованный inheritedLn, WithNetListener, WithUnixSocket, TCP порт.
+func (l *HTTPListener) bind(port int, handler http.Handler, inheritedLn net.Listener) (*boundServer, error) {
 	for i := len(l.mw) - 1; i >= 0; i-- {
 		handler = l.mw[i](handler)
 	}
 
-	server := &http.Server{
+	if l.recoverPanics {
+		handler = recoverMiddleware(l.logger)(handler)
+	}
+
+	b := &boundServer{
+		active: newInflight(),
+	}
+	handler = b.active.middleware(handler)
+
+	b.server = &http.Server{
 		Addr:           fmt.Sprintf(":%d", port),
 		Handler:        handler,
 		ReadTimeout:    defaultReadTimeout,
@@ -48,39 +89,78 @@ func (l *HTTPListener) Listen(ctx context.Context, port int, handler http.Handle
 	}
 
 	for _, opt := range l.server {
-		opt(server)
+		opt(b.server)
 	}
 
-	errChan := make(chan error)
-	signalsChan := make(chan os.Signal, 2)
-	signal.Notify(signalsChan, shutdownSignals...)
+	if l.h2c {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetUnencryptedHTTP2(true)
+		// при TLS остается и обычный HTTP/2 через ALPN
+		protocols.SetHTTP2(true)
+		b.server.Protocols = protocols
+	}
 
-	go l.listen(errChan, server, l.netListener)
+	if l.tls != nil {
+		var err error
+		if b.reloader, err = newCertReloader(l.tls.certFile, l.tls.keyFile, l.tls.clientCAFile, l.logger); err != nil {
+			return nil, err
+		}
+
+		if b.server.TLSConfig, err = buildServerTLSConfig(l.tls, b.reloader); err != nil {
+			return nil, err
+		}
+	}
 
 	var err error
-	select {
-	case err = <-errChan:
-		_ = l.shutdown(server, l.shutdownTimeout)
 
-	case <-signalsChan:
-		_ = l.shutdown(server, l.shutdownTimeout)
-	case <-ctx.Done():
-		err = ctx.Err()
-		if e := l.shutdown(server, l.shutdownTimeout); e != nil {
-			err = e
-		}
+	switch {
+	case inheritedLn != nil:
+		b.ln = inheritedLn
+	case l.netListener != nil:
+		b.ln = l.netListener
+	case l.unixSocket != "":
+		b.ln, err = listenUnix(l.unixSocket, l.unixSocketMode)
+	default:
+		b.ln, err = net.Listen("tcp", b.server.Addr)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
-	return err
+	return b, nil
 }
 
-func (l *HTTPListener) listen(ch chan error, server *http.Server, netListener net.Listener) {
+// serve обслуживает соединения до ошибки сервера или отмены ctx, после чего останавливает сервер.
+// Остановка по отмене ctx штатная, ошибкой считаются только сбои самой остановки.
+func (l *HTTPListener) serve(ctx context.Context, b *boundServer) error {
+	if b.reloader != nil && l.tls.reloadInterval > 0 {
+		watchCtx, stopWatch := context.WithCancel(context.Background())
+		defer stopWatch()
+
+		go b.reloader.watch(watchCtx, l.tls.reloadInterval)
+	}
+
+	errChan := make(chan error, 1)
+	go l.listen(errChan, b)
+
+	select {
+	case err := <-errChan:
+		return errors.Join(err, l.shutdown(b, false))
+	case <-ctx.Done():
+		return l.shutdown(b, true)
+	}
+}
+
+func (l *HTTPListener) listen(ch chan error, b *boundServer) {
 	var err error
 
-	if netListener != nil {
-		err = server.Serve(netListener)
+	// сертификат отдается через TLSConfig.GetCertificate, поэтому файлы не передаются
+	if b.server.TLSConfig != nil {
+		err = b.server.ServeTLS(b.ln, "", "")
 	} else {
-		err = server.ListenAndServe()
+		err = b.server.Serve(b.ln)
 	}
 
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -88,17 +168,131 @@ func (l *HTTPListener) listen(ch chan error, server *http.Server, netListener ne
 	}
 }
 
-func (l *HTTPListener) shutdown(server *http.Server, timeout time.Duration) error {
+// notifyShutdown возвращает контекст, который отменяется при получении сигнала остановки
+func notifyShutdown(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	signalsChan := make(chan os.Signal, 2)
+	signal.Notify(signalsChan, shutdownSignals...)
+
+	go func() {
+		select {
+		case <-signalsChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signalsChan)
+		cancel()
+	}
+}
+
+// shutdown останавливает сервер в несколько этапов: переключает readiness, ждет preStopDelay,
+// дожидается активных запросов не дольше shutdownTimeout, затем выполняет shutdown hooks
+func (l *HTTPListener) shutdown(b *boundServer, drain bool) error {
+	// после передачи сокета новому процессу трафик продолжает обслуживаться,
+	// поэтому readiness не переключается и ждать балансировщик не нужно
+	handedOver := b.handedOver.Load()
+
+	if !handedOver {
+		for _, fn := range l.beforeShutdown {
+			fn()
+		}
+	}
+
+	if drain && !handedOver && l.preStopDelay > 0 {
+		l.info(fmt.Sprintf("waiting %s before stopping to accept connections", l.preStopDelay))
+		time.Sleep(l.preStopDelay)
+	}
+
+	l.info(fmt.Sprintf("shutting down, %d requests in flight", b.active.count()))
+
+	err := l.drain(b)
+
+	return errors.Join(err, runHooks(l.shutdownHooks))
+}
+
+func (l *HTTPListener) drain(b *boundServer) error {
 	var cancel func()
 	ctx := context.Background()
 
-	if timeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, timeout)
+	if l.shutdownTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, l.shutdownTimeout)
 		defer cancel()
 	}
 
-	server.SetKeepAlivesEnabled(false)
-	return server.Shutdown(ctx)
+	b.server.SetKeepAlivesEnabled(false)
+
+	err := b.server.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	// запросы не успели завершиться: запоминаем их до принудительного закрытия соединений
+	forced := b.active.snapshot()
+	closeErr := b.server.Close()
+
+	if l.logger != nil {
+		now := time.Now()
+		for _, req := range forced {
+			reqCtx := l.logger.WithFields(context.Background(), map[string]interface{}{
+				"method":      req.Method,
+				"path":        req.Path,
+				"remote_addr": req.RemoteAddr,
+				"duration_ms": now.Sub(req.StartedAt).Milliseconds(),
+			})
+			l.logger.Warning(reqCtx, "request force-closed on shutdown")
+		}
+	}
+
+	return errors.Join(
+		fmt.Errorf("graceful shutdown timed out after %s, %d requests force-closed: %w", l.shutdownTimeout, len(forced), err),
+		closeErr,
+	)
+}
+
+func runHooks(hooks []shutdownHook) error {
+	var errs []error
+
+	for _, hook := range hooks {
+		if err := runHook(hook); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown hook %s: %w", hook.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func runHook(hook shutdownHook) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), hook.timeout)
+	defer cancel()
+
+	// hook может не уважать контекст, поэтому таймаут соблюдаем сами
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				errCh <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+
+		errCh <- hook.fn(ctx)
+	}()
+
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *HTTPListener) info(msg string) {
+	if l.logger != nil {
+		l.logger.Info(context.Background(), msg)
+	}
 }
 
 func errorLog(logger Logger) *log.Logger {
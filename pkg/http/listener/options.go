@@ -1,6 +1,9 @@
 package listener
 
 import (
+	"context"
+	"crypto/tls"
+	"io/fs"
 	"net"
 	"net/http"
 	"time"
@@ -10,10 +13,17 @@ type Option func(*options)
 
 type options struct {
 	netListener     net.Listener
+	unixSocket      string
+	unixSocketMode  fs.FileMode
 	server          []func(server *http.Server)
 	mw              []func(handler http.Handler) http.Handler
 	shutdownTimeout time.Duration
+	preStopDelay    time.Duration
+	beforeShutdown  []func()
+	shutdownHooks   []shutdownHook
 	logger          Logger
+	tls             *tlsOptions
+	h2c             bool
 
 	recoverPanics bool
 }
@@ -62,3 +72,138 @@ func WithWriteTimeout(timeout time.Duration) Option {
 			})
 	}
 }
+
+// WithNetListener задает уже открытый сокет, порт из Listen при этом игнорируется
+func WithNetListener(ln net.Listener) Option {
+	return func(o *options) {
+		o.netListener = ln
+	}
+}
+
+// WithUnixSocket включает прослушивание unix сокета вместо TCP порта. mode задает
+// права на файл сокета, 0 оставляет права по умолчанию.
+func WithUnixSocket(path string, mode fs.FileMode) Option {
+	return func(o *options) {
+		o.unixSocket = path
+		o.unixSocketMode = mode
+	}
+}
+
+// WithShutdownTimeout задает время ожидания завершения активных запросов при остановке,
+// по его истечении оставшиеся соединения закрываются принудительно. 0 - ждать без ограничений.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.shutdownTimeout = timeout
+	}
+}
+
+// WithPreStopDelay задает паузу между вызовом WithBeforeShutdown функций и остановкой
+// приема соединений. Сервер продолжает обслуживать запросы, пока балансировщик
+// не заметит провалившуюся readiness пробу.
+func WithPreStopDelay(delay time.Duration) Option {
+	return func(o *options) {
+		o.preStopDelay = delay
+	}
+}
+
+// WithRecoverPanics включает перехват паник в обработчиках на уровне сервера, по умолчанию включен
+func WithRecoverPanics(recoverPanics bool) Option {
+	return func(o *options) {
+		o.recoverPanics = recoverPanics
+	}
+}
+
+// WithOnShutdown регистрирует функцию, которая выполняется после остановки сервера,
+// например, сброс логов или закрытие пула соединений. Функции выполняются в порядке
+// регистрации, каждая со своим таймаутом (0 - defaultHookTimeout), ошибка одной
+// не мешает выполнению остальных.
+func WithOnShutdown(name string, timeout time.Duration, fn func(ctx context.Context) error) Option {
+	return func(o *options) {
+		if timeout <= 0 {
+			timeout = defaultHookTimeout
+		}
+
+		o.shutdownHooks = append(o.shutdownHooks, shutdownHook{
+			name:    name,
+			timeout: timeout,
+			fn:      fn,
+		})
+	}
+}
+
+// WithTLS включает HTTPS с сертификатом и ключом из файлов. HTTP/2 согласуется через ALPN
+// автоматически, файлы перечитываются при изменении без перезапуска процесса.
+func WithTLS(certFile, keyFile string, opts ...TLSOption) Option {
+	return func(o *options) {
+		t := &tlsOptions{
+			certFile:       certFile,
+			keyFile:        keyFile,
+			minVersion:     tls.VersionTLS12,
+			reloadInterval: defaultCertReloadInterval,
+		}
+
+		for _, opt := range opts {
+			opt(t)
+		}
+
+		o.tls = t
+	}
+}
+
+// WithH2C включает HTTP/2 без TLS (prior knowledge) в дополнение к HTTP/1.1,
+// для работы за внутренними прокси, которые сами терминируют TLS
+func WithH2C() Option {
+	return func(o *options) {
+		o.h2c = true
+	}
+}
+
+// WithBeforeShutdown регистрирует функцию, вызываемую в начале остановки сервера,
+// например, чтобы readiness проба начала возвращать ошибку
+func WithBeforeShutdown(fn func()) Option {
+	return func(o *options) {
+		o.beforeShutdown = append(o.beforeShutdown, fn)
+	}
+}
+
+// WithConfig применяет таймауты из Config, нулевые значения таймаутов пропускаются
+func WithConfig(cfg Config) Option {
+	return func(o *options) {
+		if cfg.ReadTimeout > 0 {
+			WithReadTimeout(cfg.ReadTimeout)(o)
+		}
+
+		if cfg.WriteTimeout > 0 {
+			WithWriteTimeout(cfg.WriteTimeout)(o)
+		}
+
+		if cfg.IdleTimeout > 0 {
+			WithIdleTimeout(cfg.IdleTimeout)(o)
+		}
+
+		if cfg.ShutdownTimeout > 0 {
+			WithShutdownTimeout(cfg.ShutdownTimeout)(o)
+		}
+
+		WithPreStopDelay(cfg.PreStopDelay)(o)
+
+		if cfg.TLSCertFile != "" {
+			WithTLS(
+				cfg.TLSCertFile,
+				cfg.TLSKeyFile,
+				WithMinVersion(uint16(cfg.TLSMinVersion)),
+				WithCipherSuites(cfg.TLSCipherSuites...),
+				WithClientCA(cfg.TLSClientCAFile),
+				WithReloadInterval(cfg.TLSReloadInterval),
+			)(o)
+		}
+
+		if cfg.H2C {
+			WithH2C()(o)
+		}
+
+		if cfg.UnixSocket != "" {
+			WithUnixSocket(cfg.UnixSocket, fs.FileMode(cfg.UnixSocketMode))(o)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package listener
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// recoverMiddleware - последний рубеж обработки паник, если их не перехватил роутер.
+// http.ErrAbortHandler пробрасывается дальше: им обработчик намеренно обрывает ответ.
+func recoverMiddleware(logger Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+
+				if logger != nil {
+					ctx := logger.WithFields(r.Context(), map[string]interface{}{
+						"method": r.Method,
+						"path":   r.URL.Path,
+						"stack":  string(debug.Stack()),
+					})
+					logger.Error(ctx, fmt.Sprintf("panic serving request: %v", p))
+				}
+
+				w.WriteHeader(http.StatusInternalServerError)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package listener
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCertReloadInterval = 10 * time.Second
+
+type TLSOption func(*tlsOptions)
+
+type tlsOptions struct {
+	certFile       string
+	keyFile        string
+	clientCAFile   string
+	minVersion     uint16
+	cipherSuites   []string
+	reloadInterval time.Duration
+}
+
+// WithMinVersion задает минимальную версию TLS, по умолчанию TLS 1.2
+func WithMinVersion(version uint16) TLSOption {
+	return func(o *tlsOptions) {
+		o.minVersion = version
+	}
+}
+
+// WithCipherSuites ограничивает наборы шифров для TLS 1.2 по именам из tls.CipherSuites,
+// например TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. На TLS 1.3 не влияет.
+func WithCipherSuites(names ...string) TLSOption {
+	return func(o *tlsOptions) {
+		o.cipherSuites = names
+	}
+}
+
+// WithClientCA включает mTLS: клиенты обязаны предъявить сертификат, подписанный CA из файла.
+// Файл перечитывается при изменении вместе с сертификатом сервера.
+func WithClientCA(file string) TLSOption {
+	return func(o *tlsOptions) {
+		o.clientCAFile = file
+	}
+}
+
+// WithReloadInterval задает периодичность проверки файлов сертификата и CA клиентов на изменения,
+// 0 или отрицательное значение отключает перезагрузку
+func WithReloadInterval(interval time.Duration) TLSOption {
+	return func(o *tlsOptions) {
+		o.reloadInterval = interval
+	}
+}
+
+// ParseTLSVersion разбирает версию TLS вида "1.2" или "1.3"
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", version)
+	}
+}
+
+func buildServerTLSConfig(o *tlsOptions, reloader *certReloader) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:     o.minVersion,
+		GetCertificate: reloader.getCertificate,
+		// ServeTLS добавляет протоколы ALPN только в свою копию конфигурации,
+		// а конфигурация из GetConfigForClient должна перечислять их сама
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	if len(o.cipherSuites) > 0 {
+		suites, err := parseCipherSuites(o.cipherSuites)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.CipherSuites = suites
+	}
+
+	if o.clientCAFile != "" {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+		// CA клиентов перечитывается вместе с сертификатом, поэтому пул подставляется
+		// в копию конфигурации при каждом рукопожатии
+		base := cfg.Clone()
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := base.Clone()
+			c.ClientCAs = reloader.getClientCAs()
+
+			return c, nil
+		}
+	}
+
+	return cfg, nil
+}
+
+func parseCipherSuites(names []string) ([]uint16, error) {
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// certReloader отдает текущий сертификат сервера и пул CA клиентов и перечитывает их
+// при изменении файлов. Если новые файлы не читаются (например, записан только сертификат
+// без ключа), продолжает использоваться предыдущее содержимое.
+type certReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	logger       Logger
+
+	mu              sync.RWMutex
+	cert            *tls.Certificate
+	modTime         time.Time
+	clientCAs       *x509.CertPool
+	clientCAModTime time.Time
+}
+
+func newCertReloader(certFile, keyFile, clientCAFile string, logger Logger) (*certReloader, error) {
+	r := &certReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		logger:       logger,
+	}
+
+	if _, err := r.reloadCertificate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.reloadClientCA(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+func (r *certReloader) getClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.clientCAs
+}
+
+// reloadCertificate перечитывает сертификат, если файлы изменились, и сообщает, был ли он заменен
+func (r *certReloader) reloadCertificate() (bool, error) {
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("load certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+// reloadClientCA перечитывает CA клиентов, если файл изменился, и сообщает, был ли пул заменен
+func (r *certReloader) reloadClientCA() (bool, error) {
+	if r.clientCAFile == "" {
+		return false, nil
+	}
+
+	modTime, err := latestModTime(r.clientCAFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.clientCAs != nil && modTime.Equal(r.clientCAModTime)
+	r.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(r.clientCAFile)
+	if err != nil {
+		return false, fmt.Errorf("read client CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return false, fmt.Errorf("client CA %s: no certificates found", r.clientCAFile)
+	}
+
+	r.mu.Lock()
+	r.clientCAs = pool
+	r.clientCAModTime = modTime
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+// watch проверяет файлы каждые interval до отмены ctx
+func (r *certReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		certReloaded, certErr := r.reloadCertificate()
+		caReloaded, caErr := r.reloadClientCA()
+		if r.logger == nil {
+			continue
+		}
+
+		switch {
+		case certErr != nil:
+			r.logger.Error(ctx, "TLS certificate reload failed, keeping the previous one", certErr)
+		case certReloaded:
+			r.logger.Info(ctx, fmt.Sprintf("TLS certificate reloaded from %s", r.certFile))
+		}
+
+		switch {
+		case caErr != nil:
+			r.logger.Error(ctx, "TLS client CA reload failed, keeping the previous one", caErr)
+		case caReloaded:
+			r.logger.Info(ctx, fmt.Sprintf("TLS client CA reloaded from %s", r.clientCAFile))
+		}
+	}
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat certificate: %w", err)
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	if latest.IsZero() {
+		return time.Time{}, errors.New("certificate files are not set")
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,280 @@
+package listener
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/internal/testcert"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Error(context.Context, ...interface{})   {}
+func (nopLogger) Warning(context.Context, ...interface{}) {}
+func (nopLogger) Info(context.Context, ...interface{})    {}
+func (nopLogger) WithField(ctx context.Context, _ string, _ interface{}) context.Context {
+	return ctx
+}
+func (nopLogger) WithFields(ctx context.Context, _ map[string]interface{}) context.Context {
+	return ctx
+}
+
+// issue выпускает сертификат localhost для сервера или клиента, возвращает PEM сертификата и ключа
+func issue(t *testing.T, ca *testcert.CA, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+
+	return ca.Issue(t, testcert.Cert{
+		Serial:      serial,
+		CommonName:  "localhost",
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+		Usage:       usage,
+	})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+
+	// точность времени изменения у файловых систем разная, поэтому оно задается явно
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("chtimes %s: %v", path, err)
+	}
+}
+
+// serveTLS запускает listener с TLS на случайном порту и возвращает его адрес
+func serveTLS(t *testing.T, certFile, keyFile string, opts ...TLSOption) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	l := New(
+		With(nopLogger{}),
+		WithNetListener(ln),
+		WithTLS(certFile, keyFile, opts...),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- l.Listen(ctx, 0, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Listen() error = %v", err)
+		}
+	})
+
+	return ln.Addr().String()
+}
+
+func clientTLSConfig(ca *testcert.CA, certs ...tls.Certificate) *tls.Config {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+
+	return &tls.Config{RootCAs: pool, ServerName: "localhost", Certificates: certs}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := testcert.NewCA(t, "test-ca")
+	otherCA := testcert.NewCA(t, "test-ca")
+
+	certPEM, keyPEM := issue(t, ca, 2, x509.ExtKeyUsageServerAuth)
+	certFile, keyFile, caFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt")
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+	writeFile(t, caFile, ca.PEM, time.Now())
+
+	addr := serveTLS(t, certFile, keyFile, WithClientCA(caFile), WithReloadInterval(0))
+
+	clientPEM, clientKeyPEM := issue(t, ca, 3, x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatalf("client key pair: %v", err)
+	}
+
+	foreignPEM, foreignKeyPEM := issue(t, otherCA, 4, x509.ExtKeyUsageClientAuth)
+	foreignCert, err := tls.X509KeyPair(foreignPEM, foreignKeyPEM)
+	if err != nil {
+		t.Fatalf("foreign key pair: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		certs   []tls.Certificate
+		wantErr bool
+	}{
+		{name: "without certificate", wantErr: true},
+		{name: "certificate of another CA", certs: []tls.Certificate{foreignCert}, wantErr: true},
+		{name: "certificate of client CA", certs: []tls.Certificate{clientCert}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig(ca, tt.certs...)}}
+			defer client.CloseIdleConnections()
+
+			resp, err := client.Get("https://" + addr)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GET error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && resp.StatusCode != http.StatusNoContent {
+				t.Errorf("GET status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+			}
+		})
+	}
+}
+
+func TestTLSCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := testcert.NewCA(t, "test-ca")
+
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	initialTime := time.Now().Add(-time.Minute)
+
+	certPEM, keyPEM := issue(t, ca, 10, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, initialTime)
+	writeFile(t, keyFile, keyPEM, initialTime)
+
+	addr := serveTLS(t, certFile, keyFile, WithReloadInterval(10*time.Millisecond))
+
+	if serial := servedSerial(t, addr, ca); serial != 10 {
+		t.Fatalf("served serial = %d, want 10", serial)
+	}
+
+	// ключ от другого сертификата не подходит, сервер продолжает отдавать прежний
+	_, strayKeyPEM := issue(t, ca, 11, x509.ExtKeyUsageServerAuth)
+	writeFile(t, keyFile, strayKeyPEM, initialTime.Add(time.Second))
+	time.Sleep(50 * time.Millisecond)
+
+	if serial := servedSerial(t, addr, ca); serial != 10 {
+		t.Fatalf("served serial after broken rotation = %d, want 10", serial)
+	}
+
+	certPEM, keyPEM = issue(t, ca, 12, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, initialTime.Add(2*time.Second))
+	writeFile(t, keyFile, keyPEM, initialTime.Add(2*time.Second))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for servedSerial(t, addr, ca) != 12 {
+		if time.Now().After(deadline) {
+			t.Fatal("rotated certificate is not served")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// servedSerial подключается к серверу и возвращает серийный номер его сертификата
+func servedSerial(t *testing.T, addr string, ca *testcert.CA) int64 {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", addr, clientTLSConfig(ca))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestTLSClientCAReload(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := testcert.NewCA(t, "test-ca")
+	oldCA, newCA := testcert.NewCA(t, "test-ca"), testcert.NewCA(t, "test-ca")
+
+	certFile, keyFile, caFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt")
+	initialTime := time.Now().Add(-time.Minute)
+
+	certPEM, keyPEM := issue(t, serverCA, 20, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, initialTime)
+	writeFile(t, keyFile, keyPEM, initialTime)
+	writeFile(t, caFile, oldCA.PEM, initialTime)
+
+	addr := serveTLS(t, certFile, keyFile, WithClientCA(caFile), WithReloadInterval(10*time.Millisecond))
+
+	oldClient := clientCertificate(t, oldCA, 21)
+	newClient := clientCertificate(t, newCA, 22)
+
+	if err := get(addr, serverCA, oldClient); err != nil {
+		t.Fatalf("GET with certificate of the client CA error = %v", err)
+	}
+	if err := get(addr, serverCA, newClient); err == nil {
+		t.Fatal("GET with certificate of a CA not yet trusted succeeded")
+	}
+
+	// HTTP/2 остается доступен, хотя конфигурация отдается через GetConfigForClient
+	h2Config := clientTLSConfig(serverCA, oldClient)
+	h2Config.NextProtos = []string{"h2", "http/1.1"}
+
+	conn, err := tls.Dial("tcp", addr, h2Config)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	if proto := conn.ConnectionState().NegotiatedProtocol; proto != "h2" {
+		t.Errorf("negotiated protocol = %q, want h2", proto)
+	}
+	_ = conn.Close()
+
+	writeFile(t, caFile, newCA.PEM, initialTime.Add(time.Second))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for get(addr, serverCA, newClient) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("rotated client CA is not trusted")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := get(addr, serverCA, oldClient); err == nil {
+		t.Error("GET with certificate of the replaced client CA succeeded")
+	}
+}
+
+func clientCertificate(t *testing.T, ca *testcert.CA, serial int64) tls.Certificate {
+	t.Helper()
+
+	certPEM, keyPEM := issue(t, ca, serial, x509.ExtKeyUsageClientAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("client key pair: %v", err)
+	}
+
+	return cert
+}
+
+// get выполняет запрос к серверу с сертификатом клиента
+func get(addr string, ca *testcert.CA, cert tls.Certificate) error {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig(ca, cert)}}
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Get("https://" + addr)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
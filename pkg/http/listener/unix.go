@@ -0,0 +1,41 @@
+package listener
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// listenUnix слушает unix сокет, удаляя файл, оставшийся от упавшего процесса
+func listenUnix(path string, mode fs.FileMode) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+
+		// если сокет кто-то слушает, это не мусор, а второй экземпляр сервиса
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("%s is already in use", path)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode != 0 {
+		if err := os.Chmod(path, mode); err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("chmod socket: %w", err)
+		}
+	}
+
+	return ln, nil
+}
@@ -0,0 +1,139 @@
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultUpgradeTimeout = 30 * time.Second
+
+type filer interface {
+	File() (*os.File, error)
+}
+
+// upgrade запускает новую версию бинарника и передает ей открытые сокеты группы.
+// Возвращает nil, когда новый процесс сообщил о готовности: с этого момента оба процесса
+// принимают соединения с одних и тех же сокетов, и текущий может завершаться.
+func (g *Group) upgrade(bound []*boundServer) error {
+	files := make([]*os.File, 0, len(bound)+1)
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	names := make([]string, 0, len(bound))
+	for i, b := range bound {
+		fl, ok := b.ln.(filer)
+		if !ok {
+			return fmt.Errorf("%s listener: %T can't be passed to another process", g.members[i].name, b.ln)
+		}
+
+		f, err := fl.File()
+		if err != nil {
+			return fmt.Errorf("%s listener: %w", g.members[i].name, err)
+		}
+
+		files = append(files, f)
+		names = append(names, g.members[i].name)
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("create ready pipe: %w", err)
+	}
+	defer readyR.Close()
+	files = append(files, readyW)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolve executable: %w", err)
+	}
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(
+		upgradeEnv(),
+		envListenFDs+"="+strconv.Itoa(len(names)),
+		envListenFDNames+"="+strings.Join(names, ":"),
+		envUpgradeParentPID+"="+strconv.Itoa(os.Getpid()),
+		envUpgradeReadyFD+"="+strconv.Itoa(listenFDsStart+len(names)),
+	)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start new process: %w", err)
+	}
+
+	// после старта дочернего процесса наша копия записывающего конца не нужна,
+	// иначе чтение не получит EOF, если потомок упадет
+	_ = readyW.Close()
+	files = files[:len(files)-1]
+
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		if _, err := readyR.Read(buf); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errors.New("new process exited before becoming ready")
+			}
+
+			ready <- err
+			return
+		}
+
+		ready <- nil
+	}()
+
+	select {
+	case err = <-ready:
+	case <-time.After(g.upgradeTimeout):
+		err = fmt.Errorf("new process is not ready after %s", g.upgradeTimeout)
+	}
+
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		return err
+	}
+
+	// новый процесс переживет текущий, ждать его завершения не нужно
+	return cmd.Process.Release()
+}
+
+// upgradeEnv - окружение текущего процесса без переменных передачи сокетов
+func upgradeEnv() []string {
+	env := make([]string, 0, len(os.Environ()))
+
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		switch key {
+		case envListenFDs, envListenPID, envListenFDNames, envUpgradeParentPID, envUpgradeReadyFD:
+			continue
+		}
+
+		env = append(env, kv)
+	}
+
+	return env
+}
+
+// handOver помечает сервер как переданный новому процессу: при остановке не нужна
+// пауза перед закрытием и нельзя удалять файл unix сокета, которым пользуется потомок
+func (b *boundServer) handOver() {
+	b.handedOver.Store(true)
+
+	if unixLn, ok := b.ln.(*net.UnixListener); ok {
+		unixLn.SetUnlinkOnClose(false)
+	}
+}
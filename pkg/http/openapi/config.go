@@ -0,0 +1,9 @@
+package openapi
+
+// Config описывает настройки страницы документации, заполняется через env.Load
+type Config struct {
+	// RedocURL - адрес бандла Redoc, пустой означает DefaultRedocURL
+	RedocURL string `env:"DOCS_REDOC_URL"`
+	// RedocIntegrity - SRI хеш бандла RedocURL, считается командой make redoc-sri
+	RedocIntegrity string `env:"DOCS_REDOC_INTEGRITY"`
+}
@@ -0,0 +1,198 @@
+// Package openapi отдает спецификацию API и страницу с документацией
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SpecHandler отдает спецификацию в JSON, документ сериализуется один раз при создании
+func SpecHandler(spec *openapi3.T) (http.Handler, error) {
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal openapi spec: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = w.Write(body)
+	}), nil
+}
+
+var docsPage = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>{{.Title}}</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+  <redoc spec-url="{{.SpecURL}}"></redoc>
+  <script src="{{.ScriptURL}}"{{if .Integrity}} integrity="{{.Integrity}}"{{end}} crossorigin="anonymous"></script>
+</body>
+</html>
+`))
+
+// DefaultRedocURL - бандл Redoc зафиксированной версии, latest мог бы смениться незаметно
+const DefaultRedocURL = "https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js"
+
+type DocsOption func(*docsOptions)
+
+type docsOptions struct {
+	scriptURL string
+	integrity string
+}
+
+// WithRedoc задает адрес бандла Redoc и его SRI хеш (sha384-...). С хешем браузер
+// не выполнит скрипт, если CDN отдаст другое содержимое.
+func WithRedoc(scriptURL string, integrity string) DocsOption {
+	return func(o *docsOptions) {
+		if scriptURL != "" {
+			o.scriptURL = scriptURL
+		}
+		o.integrity = integrity
+	}
+}
+
+// DocsHandler отдает страницу Redoc, которая загружает спецификацию по specURL
+func DocsHandler(title string, specURL string, opts ...DocsOption) http.Handler {
+	o := docsOptions{scriptURL: DefaultRedocURL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = docsPage.Execute(w, struct {
+			Title     string
+			SpecURL   string
+			ScriptURL string
+			Integrity string
+		}{title, specURL, o.scriptURL, o.integrity})
+	})
+}
+
+// SecuredRoutes возвращает шаблоны маршрутов ("GET /auth/me"), операции которых требуют
+// аутентификации. Операция без security наследует глобальные требования спецификации,
+// пустое требование ({}) делает аутентификацию необязательной.
+func SecuredRoutes(spec *openapi3.T) map[string]bool {
+	routes := make(map[string]bool)
+	if spec == nil || spec.Paths == nil {
+		return routes
+	}
+
+	for p, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			security := spec.Security
+			if op.Security != nil {
+				security = *op.Security
+			}
+
+			if requiresAuth(security) {
+				routes[strings.ToUpper(method)+" "+p] = true
+			}
+		}
+	}
+
+	return routes
+}
+
+func requiresAuth(security openapi3.SecurityRequirements) bool {
+	if len(security) == 0 {
+		return false
+	}
+
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	// PermissionsExtension - расширение операции со списком разрешений, которые она требует
+	PermissionsExtension = "x-permissions"
+	// PublicExtension (x-public: true) открывает операцию без проверки разрешений
+	PublicExtension = "x-public"
+)
+
+// Permissions возвращает разрешения из x-permissions по шаблонам маршрутов ("GET /auth/me").
+// Операции без расширения в результат не попадают.
+func Permissions(spec *openapi3.T) (map[string][]string, error) {
+	routes := make(map[string][]string)
+	if spec == nil || spec.Paths == nil {
+		return routes, nil
+	}
+
+	for p, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			raw, ok := op.Extensions[PermissionsExtension]
+			if !ok {
+				continue
+			}
+
+			pattern := strings.ToUpper(method) + " " + p
+			if _, public := op.Extensions[PublicExtension]; public {
+				return nil, fmt.Errorf("%s: %s and %s are mutually exclusive", pattern, PermissionsExtension, PublicExtension)
+			}
+
+			list, ok := raw.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%s of %s: expected a list of strings", PermissionsExtension, pattern)
+			}
+
+			permissions := make([]string, 0, len(list))
+			for _, v := range list {
+				s, ok := v.(string)
+				if !ok || s == "" {
+					return nil, fmt.Errorf("%s of %s: expected a list of strings", PermissionsExtension, pattern)
+				}
+
+				permissions = append(permissions, s)
+			}
+
+			routes[pattern] = permissions
+		}
+	}
+
+	return routes, nil
+}
+
+// PublicRoutes возвращает шаблоны маршрутов операций с x-public: true
+func PublicRoutes(spec *openapi3.T) (map[string]bool, error) {
+	routes := make(map[string]bool)
+	if spec == nil || spec.Paths == nil {
+		return routes, nil
+	}
+
+	for p, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			raw, ok := op.Extensions[PublicExtension]
+			if !ok {
+				continue
+			}
+
+			pattern := strings.ToUpper(method) + " " + p
+
+			public, ok := raw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%s of %s: expected a boolean", PublicExtension, pattern)
+			}
+
+			if public {
+				routes[pattern] = true
+			}
+		}
+	}
+
+	return routes, nil
+}
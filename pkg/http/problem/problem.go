@@ -0,0 +1,143 @@
+// Package problem описывает единый формат ошибок HTTP API по RFC 9457 (application/problem+json)
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/siyoga/rollstory/pkg/http/requestid"
+)
+
+// ContentType - тип содержимого ответа с ошибкой
+const ContentType = "application/problem+json"
+
+// DefaultType используется, когда у ошибки нет собственного URI типа,
+// в этом случае title совпадает с текстом HTTP статуса
+const DefaultType = "about:blank"
+
+// Problem - тело ответа с ошибкой
+type Problem struct {
+	// Type - URI, идентифицирующий вид ошибки
+	Type string `json:"type"`
+	// Title - короткое описание вида ошибки, одинаковое для всех его вхождений
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail - описание конкретного вхождения ошибки, безопасное для показа клиенту
+	Detail string `json:"detail,omitempty"`
+	// Code - машиночитаемый код ошибки предметной области
+	Code string `json:"code,omitempty"`
+	// Instance - путь запроса, на котором произошла ошибка
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// IncidentID - идентификатор записи о внутренней ошибке в логах
+	IncidentID string `json:"incident_id,omitempty"`
+	// Errors - ошибки отдельных полей запроса
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError описывает ошибку в конкретном поле, параметре или заголовке запроса
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrorer реализуют ошибки, которые содержат ошибки отдельных полей запроса
+type FieldErrorer interface {
+	FieldErrors() []FieldError
+}
+
+// New создает Problem для HTTP статуса с типом about:blank
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   DefaultType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// BadRequest создает Problem со статусом 400, ошибки полей извлекаются из err, если она реализует FieldErrorer
+func BadRequest(err error) *Problem {
+	p := New(http.StatusBadRequest, "")
+	if err == nil {
+		return p
+	}
+
+	p.Detail = err.Error()
+
+	var fe FieldErrorer
+	if errors.As(err, &fe) {
+		p.Errors = fe.FieldErrors()
+	}
+
+	return p
+}
+
+// Internal создает Problem со статусом 500. Причина ошибки клиенту не раскрывается.
+func Internal() *Problem {
+	return New(http.StatusInternalServerError, "internal error")
+}
+
+// Error позволяет возвращать Problem как ошибку
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Title)
+	}
+
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+}
+
+// ForRequest возвращает копию Problem, дополненную путем и идентификатором запроса
+func (p *Problem) ForRequest(r *http.Request) *Problem {
+	cp := *p
+
+	if r != nil {
+		if cp.Instance == "" && r.URL != nil {
+			cp.Instance = r.URL.Path
+		}
+		if cp.RequestID == "" {
+			cp.RequestID = requestid.FromContext(r.Context())
+		}
+	}
+
+	return &cp
+}
+
+// Write отправляет Problem как ответ на запрос r
+func (p *Problem) Write(w http.ResponseWriter, r *http.Request) error {
+	body, err := json.Marshal(p.ForRequest(r))
+	if err != nil {
+		return fmt.Errorf("marshal problem: %w", err)
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	_, err = w.Write(body)
+	return err
+}
+
+// ValidationError - ошибка валидации запроса с ошибками отдельных полей
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+}
+
+// Validation создает ошибку валидации для одного поля
+func Validation(field string, message string) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf("invalid %s", field),
+		Fields:  []FieldError{{Field: field, Message: message}},
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func (e *ValidationError) FieldErrors() []FieldError {
+	return e.Fields
+}
@@ -0,0 +1,48 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header - заголовок, в котором идентификатор запроса принимается от клиента
+// или прокси и возвращается в ответе
+const Header = "X-Request-Id"
+
+// maxLength ограничивает длину принятого извне идентификатора, чтобы он не раздувал логи
+const maxLength = 128
+
+type key struct{}
+
+// New генерирует новый идентификатор запроса
+func New() string {
+	return uuid.NewString()
+}
+
+// WithContext сохраняет идентификатор запроса в контексте
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext возвращает идентификатор текущего запроса или пустую строку
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
+
+// Valid проверяет, что идентификатор от клиента можно безопасно логировать и возвращать:
+// непустой, не длиннее maxLength и только из печатных ASCII символов
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
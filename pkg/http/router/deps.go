@@ -6,4 +6,5 @@ import (
 
 type Logger interface {
 	Error(ctx context.Context, args ...interface{})
+	WithFields(ctx context.Context, fields map[string]interface{}) context.Context
 }
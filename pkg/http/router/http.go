@@ -1,6 +1,15 @@
 package router
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Middleware = func(string, http.Handler) http.Handler
 
 type router struct {
 	router *http.ServeMux
@@ -9,14 +18,63 @@ type router struct {
 	badReqHandler      BadRequestErrHandler
 	internalErrHandler InternalErrHandler
 
-	middlewares []func(string, http.Handler) http.Handler
+	mu     sync.Mutex
+	root   *group
+	routes []*route
+	sealed bool
+	once   sync.Once
 }
 
 type Router interface {
+	// Use добавляет middleware ко всем маршрутам группы, включая уже зарегистрированные
 	Use(middleware func(string, http.Handler) http.Handler)
 	Handle(pattern string, handler http.Handler)
 	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
 	ServeHTTP(w http.ResponseWriter, req *http.Request)
+
+	// Method регистрирует обработчик для метода и пути, path указывается относительно группы
+	Method(method string, path string, handler http.Handler)
+	Get(path string, handler http.HandlerFunc)
+	Post(path string, handler http.HandlerFunc)
+	Put(path string, handler http.HandlerFunc)
+	Patch(path string, handler http.HandlerFunc)
+	Delete(path string, handler http.HandlerFunc)
+
+	// Group создает вложенную группу маршрутов с префиксом пути и своим набором middleware,
+	// middleware родительских групп выполняются раньше
+	Group(prefix string) Router
+	// Routes возвращает все зарегистрированные маршруты
+	Routes() []Route
+
+	// BadRequest и InternalError отвечают ошибкой в формате application/problem+json
+	// через обработчики, переданные в NewRouter
+	BadRequest(w http.ResponseWriter, req *http.Request, err error)
+	InternalError(w http.ResponseWriter, req *http.Request, err error)
+}
+
+// Route описывает зарегистрированный маршрут
+type Route struct {
+	Method  string
+	Pattern string
+	// Middlewares - количество middleware, применяемых к маршруту
+	Middlewares int
+}
+
+// group - набор маршрутов с общим префиксом и middleware
+type group struct {
+	r           *router
+	parent      *group
+	prefix      string
+	middlewares []Middleware
+}
+
+type route struct {
+	pattern string
+	method  string
+	handler http.Handler
+	group   *group
+
+	composed http.Handler
 }
 
 func NewRouter(
@@ -30,37 +88,211 @@ func NewRouter(
 		logger:             logger,
 		badReqHandler:      badReqHandler,
 		internalErrHandler: internalErrHandler,
-		middlewares:        make([]func(string, http.Handler) http.Handler, 0),
 	}
 
+	r.root = &group{r: r}
+
 	r.Use(panicMiddleware(internalErrHandler, panicHandler))
 
 	return r
 }
 
-// Use добавляет middleware в цепочку
+// Use добавляет middleware в цепочку корневой группы
 func (r *router) Use(middleware func(string, http.Handler) http.Handler) {
-	r.middlewares = append(r.middlewares, middleware)
+	r.root.Use(middleware)
 }
 
-// Handle регистрирует обработчик с применением всех middlewares
+// Handle регистрирует обработчик, middlewares применяются перед началом обслуживания запросов
 func (r *router) Handle(pattern string, handler http.Handler) {
-	finalHandler := handler
+	r.root.Handle(pattern, handler)
+}
 
-	// Применяем middlewares в обратном порядке (последний добавленный выполнится первым)
-	for i := len(r.middlewares) - 1; i >= 0; i-- {
-		finalHandler = r.middlewares[i](pattern, finalHandler)
-	}
+// HandleFunc регистрирует функцию-обработчик
+func (r *router) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.root.HandleFunc(pattern, handler)
+}
 
-	r.router.Handle(pattern, finalHandler)
+func (r *router) Method(method string, path string, handler http.Handler) {
+	r.root.Method(method, path, handler)
 }
 
-// HandleFunc регистрирует функцию-обработчик с применением всех middlewares
-func (r *router) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
-	r.Handle(pattern, http.HandlerFunc(handler))
+func (r *router) Get(path string, handler http.HandlerFunc)    { r.root.Get(path, handler) }
+func (r *router) Post(path string, handler http.HandlerFunc)   { r.root.Post(path, handler) }
+func (r *router) Put(path string, handler http.HandlerFunc)    { r.root.Put(path, handler) }
+func (r *router) Patch(path string, handler http.HandlerFunc)  { r.root.Patch(path, handler) }
+func (r *router) Delete(path string, handler http.HandlerFunc) { r.root.Delete(path, handler) }
+
+func (r *router) Group(prefix string) Router {
+	return r.root.Group(prefix)
 }
 
-// ServeHTTP реализует интерфейс http.Handler
+func (r *router) Routes() []Route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	routes := make([]Route, 0, len(r.routes))
+	for _, rt := range r.routes {
+		routes = append(routes, Route{
+			Method:      rt.method,
+			Pattern:     rt.pattern,
+			Middlewares: len(rt.group.chain()),
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Pattern < routes[j].Pattern
+	})
+
+	return routes
+}
+
+// ServeHTTP реализует интерфейс http.Handler. Первый запрос фиксирует цепочки middleware,
+// после этого Use вызывает панику.
 func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.once.Do(r.seal)
 	r.router.ServeHTTP(w, req)
 }
+
+// seal собирает обработчики всех маршрутов с их middleware
+func (r *router) seal() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rt := range r.routes {
+		rt.compose()
+	}
+
+	r.sealed = true
+}
+
+func (g *group) Use(middleware func(string, http.Handler) http.Handler) {
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+
+	// цепочки уже собраны, новый middleware молча не применился бы к маршрутам
+	if g.r.sealed {
+		panic(fmt.Sprintf("router: Use called on group %q after the router started serving requests", g.fullPrefix()))
+	}
+
+	g.middlewares = append(g.middlewares, middleware)
+}
+
+func (g *group) Handle(pattern string, handler http.Handler) {
+	method, p := splitPattern(pattern)
+
+	full := g.join(p)
+	if method != "" {
+		full = method + " " + full
+	}
+
+	rt := &route{
+		pattern: full,
+		method:  method,
+		handler: handler,
+		group:   g,
+	}
+
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+
+	// после начала обслуживания цепочка собирается до регистрации, иначе параллельный запрос
+	// попадет в маршрут без обработчика
+	if g.r.sealed {
+		rt.compose()
+	}
+
+	// маршрут регистрируется сразу, чтобы конфликты шаблонов обнаруживались при регистрации,
+	// а цепочка middleware собирается при первом запросе
+	g.r.router.Handle(full, rt)
+	g.r.routes = append(g.r.routes, rt)
+}
+
+func (g *group) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	g.Handle(pattern, http.HandlerFunc(handler))
+}
+
+func (g *group) Method(method string, path string, handler http.Handler) {
+	g.Handle(strings.ToUpper(method)+" "+path, handler)
+}
+
+func (g *group) Get(path string, handler http.HandlerFunc)    { g.Method(http.MethodGet, path, handler) }
+func (g *group) Post(path string, handler http.HandlerFunc)   { g.Method(http.MethodPost, path, handler) }
+func (g *group) Put(path string, handler http.HandlerFunc)    { g.Method(http.MethodPut, path, handler) }
+func (g *group) Patch(path string, handler http.HandlerFunc)  { g.Method(http.MethodPatch, path, handler) }
+func (g *group) Delete(path string, handler http.HandlerFunc) { g.Method(http.MethodDelete, path, handler) }
+
+func (g *group) Group(prefix string) Router {
+	return &group{
+		r:      g.r,
+		parent: g,
+		prefix: prefix,
+	}
+}
+
+func (g *group) Routes() []Route {
+	return g.r.Routes()
+}
+
+func (g *group) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	g.r.ServeHTTP(w, req)
+}
+
+// chain возвращает middleware от корневой группы к текущей
+func (g *group) chain() []Middleware {
+	var chain []Middleware
+	if g.parent != nil {
+		chain = g.parent.chain()
+	}
+
+	// новый срез, чтобы не писать в массив middleware родительской группы
+	return append(chain[:len(chain):len(chain)], g.middlewares...)
+}
+
+func (g *group) fullPrefix() string {
+	if g.parent == nil {
+		return g.prefix
+	}
+
+	return path.Join("/", g.parent.fullPrefix(), g.prefix)
+}
+
+// join добавляет префиксы групп к пути маршрута, сохраняя завершающий слеш
+// (в http.ServeMux он означает совпадение по префиксу)
+func (g *group) join(p string) string {
+	prefix := g.fullPrefix()
+	if prefix == "" {
+		return p
+	}
+
+	joined := path.Join("/", prefix, p)
+	if strings.HasSuffix(p, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+
+	return joined
+}
+
+func (rt *route) compose() {
+	handler := rt.handler
+	chain := rt.group.chain()
+
+	// Применяем middlewares в обратном порядке, чтобы первый добавленный выполнялся первым
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](rt.pattern, handler)
+	}
+
+	rt.composed = handler
+}
+
+func (rt *route) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rt.composed.ServeHTTP(w, req)
+}
+
+// splitPattern отделяет метод от пути в шаблоне http.ServeMux вида "GET /path"
+func splitPattern(pattern string) (method string, p string) {
+	if before, after, ok := strings.Cut(pattern, " "); ok {
+		return before, strings.TrimLeft(after, " ")
+	}
+
+	return "", pattern
+}
@@ -3,46 +3,82 @@ package router
 import (
 	"context"
 	"fmt"
-	"github.com/siyoga/rollstory/pkg/http/router/response"
 	"net/http"
-	"runtime/debug"
-)
 
-type DefaultErr struct {
-	Message string `json:"message"`
-	Code    int    `json:"code"`
-}
+	"github.com/siyoga/rollstory/pkg/http/problem"
+	"github.com/siyoga/rollstory/pkg/http/router/response"
+)
 
-type BadRequestErrHandler func(err error, resp response.Builder)
+// BadRequestErrHandler формирует ответ на некорректный запрос: ошибку разбора параметров или тела
+type BadRequestErrHandler func(req *http.Request, err error, resp response.Builder)
 
-type InternalErrHandler func(err interface{}, resp response.Builder)
+// InternalErrHandler формирует ответ на внутреннюю ошибку, err - ошибка обработчика или значение паники
+type InternalErrHandler func(req *http.Request, err interface{}, resp response.Builder)
 
-type PanicHandler func(ctx context.Context, e interface{})
+// PanicHandler получает перехваченную панику до отправки ответа, например для логирования
+type PanicHandler func(ctx context.Context, p *Panic)
 
 var (
-	DefaultInternalErrHandler = func(err interface{}, resp response.Builder) {
-		resp.
-			SetHeader("Content-Type", "application/json").
-			SetJsonBody(&DefaultErr{
-				Message: "internal error",
-				Code:    http.StatusInternalServerError,
-			}).
-			SetStatusCode(http.StatusInternalServerError)
+	DefaultInternalErrHandler = func(req *http.Request, err interface{}, resp response.Builder) {
+		p := problem.Internal()
+		if pnc, ok := err.(*Panic); ok {
+			p.IncidentID = pnc.IncidentID
+			p.RequestID = pnc.RequestID
+		}
+
+		writeProblem(req, p, resp)
 	}
 
-	DefaultBadRequestErrHandler = func(err error, resp response.Builder) {
-		resp.
-			SetHeader("Content-Type", "application/json").
-			SetJsonBody(&DefaultErr{
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			}).
-			SetStatusCode(http.StatusBadRequest)
+	DefaultBadRequestErrHandler = func(req *http.Request, err error, resp response.Builder) {
+		writeProblem(req, problem.BadRequest(err), resp)
 	}
 
-	DefaultPanicHandler = func(logger Logger) func(ctx context.Context, e interface{}) {
-		return func(ctx context.Context, e interface{}) {
-			logger.Error(ctx, fmt.Sprintf("Recover after panic: %+v\n%s", e, debug.Stack()))
+	DefaultPanicHandler = func(logger Logger) PanicHandler {
+		return func(ctx context.Context, p *Panic) {
+			ctx = logger.WithFields(ctx, map[string]interface{}{
+				"panic_value":      fmt.Sprintf("%+v", p.Value),
+				"panic_stack":      p.StackStrings(),
+				"http_route":       p.Pattern,
+				"request_id":       p.RequestID,
+				"incident_id":      p.IncidentID,
+				"response_started": p.ResponseStarted,
+			})
+			logger.Error(ctx, "Recover after panic")
 		}
 	}
 )
+
+// BadRequest отвечает на запрос через BadRequestErrHandler роутера.
+// Подходит для RequestErrorHandlerFunc и ErrorHandlerFunc сгенерированного сервера.
+func (r *router) BadRequest(w http.ResponseWriter, req *http.Request, err error) {
+	resp := response.NewResponse()
+	r.badReqHandler(req, err, resp)
+	_ = resp.Send(w)
+}
+
+// InternalError логирует ошибку и отвечает через InternalErrHandler роутера.
+// Подходит для ResponseErrorHandlerFunc сгенерированного сервера.
+func (r *router) InternalError(w http.ResponseWriter, req *http.Request, err error) {
+	if r.logger != nil {
+		r.logger.Error(req.Context(), "request failed", err)
+	}
+
+	resp := response.NewResponse()
+	r.internalErrHandler(req, err, resp)
+	_ = resp.Send(w)
+}
+
+func (g *group) BadRequest(w http.ResponseWriter, req *http.Request, err error) {
+	g.r.BadRequest(w, req, err)
+}
+
+func (g *group) InternalError(w http.ResponseWriter, req *http.Request, err error) {
+	g.r.InternalError(w, req, err)
+}
+
+func writeProblem(req *http.Request, p *problem.Problem, resp response.Builder) {
+	resp.
+		SetHeader("Content-Type", problem.ContentType).
+		SetJsonBody(p.ForRequest(req)).
+		SetStatusCode(p.Status)
+}
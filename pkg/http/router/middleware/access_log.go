@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// AccessLog пишет по строке на каждый запрос: шаблон маршрута, статус, размер ответа и время.
+// 5xx логируются как ошибки. Запрос, завершившийся паникой, логируется со статусом 500,
+// сама паника пробрасывается дальше.
+func AccessLog(logger Logger) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newResponseRecorder(w)
+			start := time.Now()
+			completed := false
+
+			// паника не перехватывается, чтобы не потерять стек для обработчика паник роутера
+			defer func() {
+				status := rec.Status()
+				switch {
+				case !completed && status == 0:
+					status = http.StatusInternalServerError
+				case status == 0:
+					status = http.StatusOK
+				}
+
+				ctx := logger.WithFields(r.Context(), map[string]interface{}{
+					"http_method":      r.Method,
+					"http_pattern":     pattern,
+					"http_path":        r.URL.Path,
+					"http_status":      status,
+					"http_bytes":       rec.bytes,
+					"http_duration_ms": time.Since(start).Milliseconds(),
+					"http_remote_addr": r.RemoteAddr,
+					"http_user_agent":  r.UserAgent(),
+				})
+
+				if status >= http.StatusInternalServerError {
+					logger.Error(ctx, "request completed")
+				} else {
+					logger.Info(ctx, "request completed")
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+			completed = true
+		})
+	}
+}
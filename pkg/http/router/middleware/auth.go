@@ -0,0 +1,257 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/http/problem"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+const defaultMaxSignedBodyBytes = 1 << 20
+
+// TokenVerifier реализуется *auth.TokenIssuer
+type TokenVerifier interface {
+	Verify(token string) (*auth.Claims, error)
+}
+
+// APIKeyVerifier проверяет API ключи и подписанные ими запросы. Неверные учетные данные
+// возвращаются как auth.ErrInvalidAPIKey, auth.ErrExpiredAPIKey или auth.ErrInvalidSignature.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*auth.APIKeyPrincipal, error)
+	VerifySignature(ctx context.Context, prefix string, timestamp int64, nonce string, base []byte, signature []byte) (*auth.APIKeyPrincipal, error)
+}
+
+// SessionLoader реализуется *session.Manager
+type SessionLoader interface {
+	Load(ctx context.Context, r *http.Request) (*session.Session, error)
+	ClearCookie() http.Cookie
+}
+
+// AuthOptions настраивает Authenticate
+type AuthOptions struct {
+	Verifier TokenVerifier
+	// APIKeys включает аутентификацию API ключом (X-Api-Key) и подписью запроса (X-Signature*)
+	APIKeys APIKeyVerifier
+	// MaxSignedBodyBytes ограничивает тело, которое читается для проверки подписи, по умолчанию 1 МБ
+	MaxSignedBodyBytes int64
+	// Sessions включает аутентификацию cookie сессии для запросов без Authorization
+	Sessions SessionLoader
+	// Required - шаблоны маршрутов, доступных только аутентифицированным пользователям,
+	// см. openapi.SecuredRoutes
+	Required map[string]bool
+	Logger   Logger
+}
+
+// Authenticate проверяет учетные данные запроса и кладет идентификатор пользователя в контекст
+// (auth.UserIDFromContext) и в поле лога user_id, а роли - в контекст (auth.RolesFromContext).
+// Принимаются access токен в Authorization: Bearer, API ключ в X-Api-Key и подпись запроса
+// секретом API ключа; для ключей в контекст попадают и их области (auth.ScopesFromContext).
+// Запрос без Authorization может быть аутентифицирован cookie сессии, сессия попадает
+// в контекст (session.FromContext).
+// Неверные учетные данные отклоняются с 401 на любом маршруте, их отсутствие - только
+// на маршрутах из Required. Недействительный cookie сессии удаляется, а запрос
+// обрабатывается как анонимный: браузер присылает cookie сам, и после завершения сессии
+// открытые маршруты не должны отвечать ошибкой.
+func Authenticate(opts AuthOptions) Middleware {
+	if opts.MaxSignedBodyBytes <= 0 {
+		opts.MaxSignedBodyBytes = defaultMaxSignedBodyBytes
+	}
+
+	return func(pattern string, next http.Handler) http.Handler {
+		required := opts.Required[pattern]
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.APIKeys != nil {
+				if r.Header.Get(auth.HeaderSignatureKey) != "" {
+					authenticateSignature(w, r, opts, next)
+					return
+				}
+
+				if key := r.Header.Get(auth.HeaderAPIKey); key != "" {
+					principal, err := opts.APIKeys.VerifyAPIKey(r.Context(), key)
+					if err != nil {
+						apiKeyFailed(w, r, opts, "ApiKey", err)
+						return
+					}
+
+					next.ServeHTTP(w, r.WithContext(withAPIKeyPrincipal(r.Context(), principal, opts.Logger)))
+					return
+				}
+			}
+
+			token, ok := bearerToken(r)
+			if !ok && opts.Sessions != nil {
+				s, err := opts.Sessions.Load(r.Context(), r)
+				switch {
+				case err == nil:
+					next.ServeHTTP(w, r.WithContext(withSession(r.Context(), s, opts.Logger)))
+					return
+				case errors.Is(err, session.ErrInvalidSession):
+					cookie := opts.Sessions.ClearCookie()
+					http.SetCookie(w, &cookie)
+				case !errors.Is(err, session.ErrNoSession):
+					if opts.Logger != nil {
+						opts.Logger.Error(r.Context(), fmt.Sprintf("session load failed: %s", err))
+					}
+
+					_ = problem.Internal().Write(w, r)
+					return
+				}
+			}
+
+			if !ok {
+				if required {
+					unauthorized(w, r, `Bearer`, "authentication required")
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := opts.Verifier.Verify(token)
+			if err != nil {
+				detail := "invalid access token"
+				if errors.Is(err, auth.ErrExpiredToken) {
+					detail = "access token expired"
+				}
+
+				unauthorized(w, r, `Bearer error="invalid_token"`, detail)
+				return
+			}
+
+			ctx := auth.WithRoles(auth.WithUserID(r.Context(), claims.Subject), claims.Roles)
+			if opts.Logger != nil {
+				ctx = opts.Logger.WithField(ctx, "user_id", claims.Subject)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// authenticateSignature проверяет подпись auth.SignRequest. Тело читается целиком,
+// чтобы посчитать его хеш, и подставляется обратно для обработчика.
+func authenticateSignature(w http.ResponseWriter, r *http.Request, opts AuthOptions, next http.Handler) {
+	timestamp, err := strconv.ParseInt(r.Header.Get(auth.HeaderSignatureTimestamp), 10, 64)
+	if err != nil {
+		unauthorized(w, r, `Signature`, fmt.Sprintf("invalid %s header", auth.HeaderSignatureTimestamp))
+		return
+	}
+
+	nonce := r.Header.Get(auth.HeaderSignatureNonce)
+	if nonce == "" || len(nonce) > auth.MaxNonceLength {
+		unauthorized(w, r, `Signature`, fmt.Sprintf("invalid %s header", auth.HeaderSignatureNonce))
+		return
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(auth.HeaderSignature))
+	if err != nil || len(signature) == 0 {
+		unauthorized(w, r, `Signature`, fmt.Sprintf("invalid %s header", auth.HeaderSignature))
+		return
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxSignedBodyBytes))
+		if err != nil {
+			var maxBytes *http.MaxBytesError
+			if errors.As(err, &maxBytes) {
+				_ = problem.New(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBytes.Limit)).Write(w, r)
+				return
+			}
+
+			_ = problem.New(http.StatusBadRequest, "failed to read request body").Write(w, r)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	base := auth.SignatureBase(r.Method, r.URL.RequestURI(), timestamp, nonce, body)
+
+	principal, err := opts.APIKeys.VerifySignature(r.Context(), r.Header.Get(auth.HeaderSignatureKey), timestamp, nonce, base, signature)
+	if err != nil {
+		apiKeyFailed(w, r, opts, "Signature", err)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(withAPIKeyPrincipal(r.Context(), principal, opts.Logger)))
+}
+
+func withAPIKeyPrincipal(ctx context.Context, p *auth.APIKeyPrincipal, logger Logger) context.Context {
+	ctx = auth.WithUserID(ctx, p.UserID)
+	ctx = auth.WithRoles(ctx, p.Roles)
+	ctx = auth.WithScopes(ctx, p.Scopes)
+	ctx = auth.WithAPIKeyID(ctx, p.KeyID)
+
+	if logger != nil {
+		ctx = logger.WithFields(ctx, map[string]interface{}{
+			"user_id":    p.UserID,
+			"api_key_id": p.KeyID,
+		})
+	}
+
+	return ctx
+}
+
+func withSession(ctx context.Context, s *session.Session, logger Logger) context.Context {
+	ctx = auth.WithUserID(ctx, s.UserID)
+	ctx = auth.WithRoles(ctx, s.Roles)
+	ctx = session.WithSession(ctx, s)
+
+	if logger != nil {
+		ctx = logger.WithFields(ctx, map[string]interface{}{
+			"user_id":    s.UserID,
+			"session_id": s.ID,
+		})
+	}
+
+	return ctx
+}
+
+// apiKeyFailed отвечает 401 на неверные учетные данные, остальные ошибки проверки - внутренние
+func apiKeyFailed(w http.ResponseWriter, r *http.Request, opts AuthOptions, scheme string, err error) {
+	switch {
+	case errors.Is(err, auth.ErrExpiredAPIKey):
+		unauthorized(w, r, scheme, "api key expired")
+	case errors.Is(err, auth.ErrInvalidAPIKey):
+		unauthorized(w, r, scheme, "invalid api key")
+	case errors.Is(err, auth.ErrInvalidSignature):
+		unauthorized(w, r, scheme, "invalid request signature")
+	default:
+		if opts.Logger != nil {
+			opts.Logger.Error(r.Context(), fmt.Sprintf("api key verification failed: %s", err))
+		}
+
+		_ = problem.Internal().Write(w, r)
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, challenge string, detail string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+
+	p := problem.New(http.StatusUnauthorized, detail)
+	p.Code = "unauthorized"
+	_ = p.Write(w, r)
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/http/problem"
+	"github.com/siyoga/rollstory/pkg/policy"
+)
+
+// PolicyEvaluator реализуется *policy.Engine
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, req policy.Request) policy.Decision
+}
+
+// AuthorizeOptions настраивает Authorize
+type AuthorizeOptions struct {
+	Policy PolicyEvaluator
+	// Permissions - разрешения, которые требуются для маршрута, см. openapi.Permissions
+	Permissions map[string][]string
+	// Public - маршруты, открытые без проверки разрешений, см. openapi.PublicRoutes
+	Public map[string]bool
+	Logger Logger
+}
+
+// Authorize проверяет, что роли пользователя дают все разрешения маршрута: анонимный
+// запрос получает 401, а нехватка разрешения - 403. Маршрут должен либо перечислить
+// разрешения, либо быть открытым явно (Public), на любой другой маршрут отвечается 403.
+// Должен стоять после Authenticate.
+func Authorize(opts AuthorizeOptions) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		required := opts.Permissions[pattern]
+		if len(required) == 0 {
+			if opts.Public[pattern] {
+				return next
+			}
+
+			if opts.Logger != nil {
+				opts.Logger.Warning(context.Background(), fmt.Sprintf("route %s declares no permissions and isn't public, every request is denied", pattern))
+			}
+
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				p := problem.New(http.StatusForbidden, "no access rule for this route")
+				p.Code = "forbidden"
+				_ = p.Write(w, r)
+			})
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := auth.UserIDFromContext(r.Context())
+			if !ok {
+				unauthorized(w, r, `Bearer`, "authentication required")
+				return
+			}
+
+			subject := policy.Subject{ID: userID}
+			for _, role := range auth.RolesFromContext(r.Context()) {
+				subject.Roles = append(subject.Roles, policy.Role(role))
+			}
+			if scopes, ok := auth.ScopesFromContext(r.Context()); ok {
+				subject.Scopes = make([]policy.Permission, 0, len(scopes))
+				for _, s := range scopes {
+					subject.Scopes = append(subject.Scopes, policy.Permission(s))
+				}
+			}
+
+			for _, permission := range required {
+				d := opts.Policy.Evaluate(r.Context(), policy.Request{
+					Subject:    subject,
+					Permission: policy.Permission(permission),
+				})
+				if d.Allowed {
+					continue
+				}
+
+				p := problem.New(http.StatusForbidden, fmt.Sprintf("missing permission %s", permission))
+				p.Code = "forbidden"
+				_ = p.Write(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
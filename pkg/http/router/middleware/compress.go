@@ -0,0 +1,277 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+
+	defaultCompressMinSize = 1024
+)
+
+var defaultCompressTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+	"text/",
+}
+
+var (
+	gzipPool = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+
+	zstdPool = sync.Pool{New: func() any {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedDefault))
+		return w
+	}}
+)
+
+// CompressOptions - настройки сжатия ответов
+type CompressOptions struct {
+	// MinSize - минимальный размер ответа для сжатия, по умолчанию 1 КБ
+	MinSize int
+	// ContentTypes - префиксы сжимаемых Content-Type, по умолчанию JSON, XML и текст
+	ContentTypes []string
+}
+
+// Compress сжимает ответы в zstd или gzip, в зависимости от Accept-Encoding (zstd в приоритете).
+// Ответ буферизуется до MinSize байт, чтобы не сжимать короткие ответы; ответы, у которых
+// уже есть Content-Encoding, а также 204, 304 и HEAD не сжимаются.
+func Compress(opts CompressOptions) Middleware {
+	if opts.MinSize <= 0 {
+		opts.MinSize = defaultCompressMinSize
+	}
+
+	if len(opts.ContentTypes) == 0 {
+		opts.ContentTypes = defaultCompressTypes
+	}
+
+	return func(_ string, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				opts:           &opts,
+				encoding:       encoding,
+				status:         http.StatusOK,
+			}
+
+			next.ServeHTTP(cw, r)
+
+			// не в defer: при панике Close отправил бы 200 с буфером, и обработчик паники
+			// выше уже не смог бы ответить ошибкой
+			cw.Close()
+		})
+	}
+}
+
+// negotiateEncoding выбирает zstd или gzip по Accept-Encoding с учетом q=0
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		accepted[name] = q > 0
+	}
+
+	for _, encoding := range []string{encodingZstd, encodingGzip} {
+		if ok, listed := accepted[encoding]; listed {
+			if ok {
+				return encoding
+			}
+			continue
+		}
+
+		if accepted["*"] {
+			return encoding
+		}
+	}
+
+	return ""
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+
+	opts     *CompressOptions
+	encoding string
+	status   int
+
+	buf     []byte
+	decided bool
+	enc     io.WriteCloser
+}
+
+func (c *compressWriter) WriteHeader(code int) {
+	if c.decided {
+		return
+	}
+
+	// информационные ответы уходят сразу и не влияют на основной
+	if code >= 100 && code < 200 {
+		c.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	c.status = code
+
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		c.decide(false)
+	}
+}
+
+func (c *compressWriter) Write(p []byte) (int, error) {
+	if !c.decided {
+		c.buf = append(c.buf, p...)
+		if len(c.buf) < c.opts.MinSize {
+			return len(p), nil
+		}
+
+		if err := c.start(true); err != nil {
+			return 0, err
+		}
+
+		return len(p), nil
+	}
+
+	if c.enc != nil {
+		return c.enc.Write(p)
+	}
+
+	return c.ResponseWriter.Write(p)
+}
+
+func (c *compressWriter) Flush() {
+	if !c.decided {
+		// стриминг: сжимаем, не дожидаясь MinSize, если тип подходит
+		_ = c.start(true)
+	}
+
+	if f, ok := c.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+
+	_ = http.NewResponseController(c.ResponseWriter).Flush()
+}
+
+func (c *compressWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// Close дописывает буфер и закрывает кодировщик, вызывается по завершении обработчика
+func (c *compressWriter) Close() {
+	if !c.decided {
+		// ответ целиком меньше MinSize
+		_ = c.start(false)
+	}
+
+	if c.enc == nil {
+		return
+	}
+
+	_ = c.enc.Close()
+
+	switch enc := c.enc.(type) {
+	case *gzip.Writer:
+		enc.Reset(io.Discard)
+		gzipPool.Put(enc)
+	case *zstd.Encoder:
+		enc.Reset(nil)
+		zstdPool.Put(enc)
+	}
+
+	c.enc = nil
+}
+
+// start принимает решение о сжатии и отправляет заголовки и накопленный буфер
+func (c *compressWriter) start(compress bool) error {
+	c.decide(compress && c.compressible())
+
+	if len(c.buf) == 0 {
+		return nil
+	}
+
+	buf := c.buf
+	c.buf = nil
+
+	var err error
+	if c.enc != nil {
+		_, err = c.enc.Write(buf)
+	} else {
+		_, err = c.ResponseWriter.Write(buf)
+	}
+
+	return err
+}
+
+func (c *compressWriter) decide(compress bool) {
+	c.decided = true
+
+	if compress {
+		h := c.Header()
+		h.Set("Content-Encoding", c.encoding)
+		h.Del("Content-Length")
+
+		switch c.encoding {
+		case encodingZstd:
+			enc := zstdPool.Get().(*zstd.Encoder)
+			enc.Reset(c.ResponseWriter)
+			c.enc = enc
+		case encodingGzip:
+			enc := gzipPool.Get().(*gzip.Writer)
+			enc.Reset(c.ResponseWriter)
+			c.enc = enc
+		}
+	}
+
+	c.ResponseWriter.WriteHeader(c.status)
+}
+
+func (c *compressWriter) compressible() bool {
+	h := c.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := h.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(c.buf)
+	}
+
+	for _, prefix := range c.opts.ContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
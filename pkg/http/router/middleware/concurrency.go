@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+const (
+	defaultConcurrencyMin = 10
+	defaultConcurrencyMax = 1000
+	// concurrencyBackoff - во сколько раз уменьшается лимит при росте задержек
+	concurrencyBackoff = 0.9
+)
+
+// ConcurrencyOptions настраивает AdaptiveConcurrency
+type ConcurrencyOptions struct {
+	// MinLimit и MaxLimit ограничивают лимит одновременных запросов, начальный лимит равен MaxLimit
+	MinLimit int
+	MaxLimit int
+	// TargetLatency - задержка, выше которой сервис считается перегруженным
+	TargetLatency time.Duration
+}
+
+// AdaptiveConcurrency ограничивает число одновременно обрабатываемых запросов лимитом,
+// который подстраивается по задержкам (AIMD): пока запросы укладываются в TargetLatency,
+// лимит растет на единицу за каждые limit запросов, при превышении - уменьшается в 0.9 раза,
+// не чаще одного раза за TargetLatency. Запросы сверх лимита получают 503 с Retry-After.
+// Лимит общий для всех маршрутов, к которым применяется middleware.
+func AdaptiveConcurrency(opts ConcurrencyOptions) Middleware {
+	l := newAdaptiveLimiter(opts)
+
+	return func(pattern string, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !l.acquire() {
+				w.Header().Set("Retry-After", strconv.Itoa(1))
+
+				p := problem.New(http.StatusServiceUnavailable, "server is overloaded, retry later")
+				p.Code = "overloaded"
+				_ = p.Write(w, r)
+
+				return
+			}
+
+			start := time.Now()
+			defer func() {
+				l.release(time.Since(start))
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type adaptiveLimiter struct {
+	min, max float64
+	target   time.Duration
+
+	mu           sync.Mutex
+	limit        float64
+	inflight     int
+	lastDecrease time.Time
+}
+
+func newAdaptiveLimiter(opts ConcurrencyOptions) *adaptiveLimiter {
+	if opts.MinLimit <= 0 {
+		opts.MinLimit = defaultConcurrencyMin
+	}
+	if opts.MaxLimit < opts.MinLimit {
+		opts.MaxLimit = max(defaultConcurrencyMax, opts.MinLimit)
+	}
+
+	return &adaptiveLimiter{
+		min:    float64(opts.MinLimit),
+		max:    float64(opts.MaxLimit),
+		target: opts.TargetLatency,
+		limit:  float64(opts.MaxLimit),
+	}
+}
+
+func (l *adaptiveLimiter) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inflight >= int(l.limit) {
+		return false
+	}
+
+	l.inflight++
+	return true
+}
+
+func (l *adaptiveLimiter) release(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+
+	if l.target <= 0 {
+		return
+	}
+
+	if latency > l.target {
+		now := time.Now()
+		// запросы, начатые до уменьшения, не должны уменьшать лимит повторно
+		if now.Sub(l.lastDecrease) >= l.target {
+			l.limit = max(l.min, l.limit*concurrencyBackoff)
+			l.lastDecrease = now
+		}
+
+		return
+	}
+
+	l.limit = min(l.max, l.limit+1/l.limit)
+}
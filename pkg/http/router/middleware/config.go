@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/netip"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/ratelimit"
+)
+
+// Config описывает настройки стандартного набора middleware, заполняется через env.Load
+type Config struct {
+	RequestTimeout  time.Duration `env:"HTTP_REQUEST_TIMEOUT" default:"30s"`
+	MaxBodyBytes    int64         `env:"HTTP_MAX_BODY_BYTES" default:"1048576"`
+	CompressMinSize int           `env:"HTTP_COMPRESS_MIN_SIZE" default:"1024"`
+	// ValidateResponses проверяет ответы по OpenAPI спецификации, включается явно и никогда в production
+	ValidateResponses bool `env:"HTTP_VALIDATE_RESPONSES" default:"false"`
+
+	CORSAllowedOrigins   []string      `env:"HTTP_CORS_ALLOWED_ORIGINS"`
+	CORSAllowCredentials bool          `env:"HTTP_CORS_ALLOW_CREDENTIALS" default:"false"`
+	CORSMaxAge           time.Duration `env:"HTTP_CORS_MAX_AGE" default:"10m"`
+
+	// RateLimit - квота по умолчанию для каждого маршрута, off отключает ограничение
+	RateLimit       ratelimit.Quota       `env:"HTTP_RATE_LIMIT" default:"300/1m"`
+	RateLimitRoutes ratelimit.RouteQuotas `env:"HTTP_RATE_LIMIT_ROUTES"`
+	// RateLimitKey - чей лимит расходует запрос: IP клиента, аутентифицированный пользователь или общий на маршрут.
+	// Лимит по IP действует всегда, user и route добавляют к нему второй лимит после аутентификации.
+	RateLimitKey string `env:"HTTP_RATE_LIMIT_KEY" default:"ip" oneof:"ip,user,route"`
+	// RateLimitStore - postgres делает лимиты общими для всех инстансов
+	RateLimitStore    string `env:"HTTP_RATE_LIMIT_STORE" default:"memory" oneof:"memory,postgres"`
+	TrustForwardedFor bool   `env:"HTTP_TRUST_FORWARDED_FOR" default:"false"`
+	// TrustedProxies - сети своих прокси в X-Forwarded-For, например 10.0.0.0/8
+	TrustedProxies []netip.Prefix `env:"HTTP_TRUSTED_PROXIES"`
+
+	// AdaptiveConcurrency включает сброс нагрузки с 503 при росте задержек выше ConcurrencyTargetLatency
+	AdaptiveConcurrency      bool          `env:"HTTP_ADAPTIVE_CONCURRENCY" default:"false"`
+	ConcurrencyMin           int           `env:"HTTP_CONCURRENCY_MIN" default:"10"`
+	ConcurrencyMax           int           `env:"HTTP_CONCURRENCY_MAX" default:"1000"`
+	ConcurrencyTargetLatency time.Duration `env:"HTTP_CONCURRENCY_TARGET_LATENCY" default:"1s"`
+}
+
+// CORSOptions собирает настройки CORS из Config
+func (c Config) CORSOptions() CORSOptions {
+	return CORSOptions{
+		AllowedOrigins:   c.CORSAllowedOrigins,
+		AllowCredentials: c.CORSAllowCredentials,
+		MaxAge:           c.CORSMaxAge,
+	}
+}
+
+// IPKeyFunc возвращает KeyFunc по IP клиента с учетом настроек X-Forwarded-For
+func (c Config) IPKeyFunc() KeyFunc {
+	return KeyByIP(c.forwardedFor())
+}
+
+// RateLimitKeyFunc возвращает KeyFunc второго лимита по настройке RateLimitKey
+// или nil, если достаточно лимита по IP
+func (c Config) RateLimitKeyFunc() KeyFunc {
+	switch c.RateLimitKey {
+	case "user":
+		return KeyByUser()
+	case "route":
+		return KeyByRoute()
+	default:
+		return nil
+	}
+}
+
+func (c Config) forwardedFor() ForwardedFor {
+	return ForwardedFor{Trust: c.TrustForwardedFor, TrustedProxies: c.TrustedProxies}
+}
+
+// ConcurrencyOptions собирает настройки AdaptiveConcurrency из Config
+func (c Config) ConcurrencyOptions() ConcurrencyOptions {
+	return ConcurrencyOptions{
+		MinLimit:      c.ConcurrencyMin,
+		MaxLimit:      c.ConcurrencyMax,
+		TargetLatency: c.ConcurrencyTargetLatency,
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization", "X-Request-Id", session.HeaderCSRFToken}
+)
+
+// CORSOptions - настройки CORS. AllowedOrigins поддерживает "*" и маски поддоменов
+// вида https://*.example.com. "*" несовместим с AllowCredentials: любой сайт смог бы
+// читать ответы на запросы с cookie пользователя.
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORS оборачивает весь роутер, а не отдельные маршруты: preflight запрос OPTIONS
+// не совпадает с шаблонами вида "GET /path" и до middleware маршрута не доходит.
+// Запросы с неразрешенным Origin обрабатываются без CORS заголовков, браузер их заблокирует.
+func CORS(opts CORSOptions) (func(http.Handler) http.Handler, error) {
+	if opts.AllowCredentials {
+		for _, pattern := range opts.AllowedOrigins {
+			if matchesAnySite(pattern) {
+				return nil, fmt.Errorf("cors: allowed origin %s can't be combined with credentials, list the origins explicitly", pattern)
+			}
+		}
+	}
+
+	if len(opts.AllowedMethods) == 0 {
+		opts.AllowedMethods = defaultCORSMethods
+	}
+
+	if len(opts.AllowedHeaders) == 0 {
+		opts.AllowedHeaders = defaultCORSHeaders
+	}
+
+	allowMethods := strings.Join(opts.AllowedMethods, ", ")
+	allowHeaders := strings.Join(opts.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(opts.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+	wildcard := slices.Contains(opts.AllowedOrigins, "*")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			// ответ зависит от Origin, кеши должны это учитывать даже для запросов без него
+			w.Header().Add("Vary", "Origin")
+
+			if origin == "" || !originAllowed(opts.AllowedOrigins, origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if wildcard {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if opts.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if !preflight {
+				if exposeHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			if opts.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}, nil
+}
+
+// matchesAnySite сообщает, что шаблон Origin пропускает сайты разных владельцев:
+// "*", "https://*" или маска по домену верхнего уровня вроде "https://*.com"
+func matchesAnySite(pattern string) bool {
+	_, suffix, ok := strings.Cut(pattern, "*")
+	if !ok {
+		return false
+	}
+
+	host, _, _ := strings.Cut(strings.TrimPrefix(suffix, "."), ":")
+
+	return !strings.Contains(host, ".")
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, pattern := range allowed {
+		if pattern == "*" || strings.EqualFold(pattern, origin) {
+			return true
+		}
+
+		// https://*.example.com совпадает с https://api.example.com, но не с https://example.com
+		if prefix, suffix, ok := strings.Cut(pattern, "*"); ok {
+			if len(origin) > len(prefix)+len(suffix) &&
+				strings.HasPrefix(origin, prefix) &&
+				strings.HasSuffix(origin, suffix) &&
+				!strings.Contains(origin[len(prefix):len(origin)-len(suffix)], "/") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
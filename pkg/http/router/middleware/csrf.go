@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+	"github.com/siyoga/rollstory/pkg/session"
+)
+
+// CSRFOptions настраивает CSRF
+type CSRFOptions struct {
+	// Header - заголовок с CSRF токеном, по умолчанию session.HeaderCSRFToken
+	Header string
+	// OriginChecked - маршруты, которые начинают сессию (вход). Токена у клиента еще нет,
+	// поэтому для них проверяются Origin и Sec-Fetch-Site: иначе чужой сайт может войти
+	// в браузере пользователя под своим аккаунтом (login CSRF).
+	OriginChecked map[string]bool
+	// AllowedOrigins - сайты, кроме самого API, которым разрешены запросы к OriginChecked,
+	// в формате CORSOptions.AllowedOrigins. "*" не учитывается.
+	AllowedOrigins []string
+	Logger         Logger
+}
+
+// CSRF требует от изменяющих запросов, аутентифицированных cookie сессии, CSRF токен сессии
+// в заголовке. Cookie браузер прикладывает и к запросам, отправленным чужим сайтом, а прочитать
+// токен и выставить заголовок чужой сайт не может. Запросы с токеном доступа или API ключом
+// не проверяются: эти учетные данные браузер сам не подставляет. На маршрутах OriginChecked
+// запросы с чужих сайтов отклоняются по Origin и Sec-Fetch-Site. Должен стоять после Authenticate.
+func CSRF(opts CSRFOptions) Middleware {
+	if opts.Header == "" {
+		opts.Header = session.HeaderCSRFToken
+	}
+
+	opts.AllowedOrigins = slices.DeleteFunc(slices.Clone(opts.AllowedOrigins), func(origin string) bool {
+		return origin == "*"
+	})
+
+	return func(pattern string, next http.Handler) http.Handler {
+		if opts.OriginChecked[pattern] {
+			next = checkOrigin(opts, next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s, ok := session.FromContext(r.Context())
+			if !ok || safeMethod(r.Method) || s.ValidCSRF(r.Header.Get(opts.Header)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if opts.Logger != nil {
+				opts.Logger.Warning(r.Context(), "CSRF token mismatch")
+			}
+
+			p := problem.New(http.StatusForbidden, fmt.Sprintf("missing or invalid %s header", opts.Header))
+			p.Code = "csrf_token_invalid"
+			_ = p.Write(w, r)
+		})
+	}
+}
+
+// checkOrigin пропускает запросы с того же origin, с разрешенных сайтов и без заголовков
+// Origin и Sec-Fetch-Site: их не присылают только клиенты вне браузера
+func checkOrigin(opts CSRFOptions, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		site := r.Header.Get("Sec-Fetch-Site")
+
+		allowed := safeMethod(r.Method) ||
+			site == "same-origin" || site == "none" ||
+			(site == "" && (origin == "" || sameOrigin(r, origin))) ||
+			(origin != "" && originAllowed(opts.AllowedOrigins, origin))
+		if allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if opts.Logger != nil {
+			opts.Logger.Warning(r.Context(), fmt.Sprintf("cross-site request from origin %q rejected", origin))
+		}
+
+		p := problem.New(http.StatusForbidden, "cross-site requests are not allowed")
+		p.Code = "cross_site_request"
+		_ = p.Write(w, r)
+	})
+}
+
+// sameOrigin сравнивает Origin с хостом запроса, схему за прокси с TLS надежно не узнать
+func sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
+
+// safeMethod - методы, которые по RFC 9110 не меняют состояние сервера
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
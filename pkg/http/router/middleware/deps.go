@@ -0,0 +1,11 @@
+package middleware
+
+import "context"
+
+type Logger interface {
+	Info(ctx context.Context, args ...interface{})
+	Warning(ctx context.Context, args ...interface{})
+	Error(ctx context.Context, args ...interface{})
+	WithField(ctx context.Context, k string, v interface{}) context.Context
+	WithFields(ctx context.Context, fields map[string]interface{}) context.Context
+}
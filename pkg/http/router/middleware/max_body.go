@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+// MaxBody ограничивает размер тела запроса limit байтами, для маршрутов из routes
+// используется свой лимит (0 - без ограничения). Запросы с заведомо большим Content-Length
+// отклоняются сразу с 413, остальные получают ошибку *http.MaxBytesError при чтении тела.
+func MaxBody(limit int64, routes map[string]int64) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		n := limit
+		if routeLimit, ok := routes[pattern]; ok {
+			n = routeLimit
+		}
+
+		if n <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				_ = problem.New(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", n)).Write(w, r)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+)
+
+// Middleware совпадает с сигнатурой router.Router.Use: получает шаблон маршрута,
+// поэтому может настраиваться отдельно для каждого маршрута
+type Middleware = func(pattern string, next http.Handler) http.Handler
+
+// Only применяет mw только к маршрутам из patterns
+func Only(mw Middleware, patterns ...string) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		if !slices.Contains(patterns, pattern) {
+			return next
+		}
+
+		return mw(pattern, next)
+	}
+}
+
+// Except применяет mw ко всем маршрутам, кроме patterns
+func Except(mw Middleware, patterns ...string) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		if slices.Contains(patterns, pattern) {
+			return next
+		}
+
+		return mw(pattern, next)
+	}
+}
+
+// Chain объединяет middleware в одну, первая в списке выполняется первой
+func Chain(mws ...Middleware) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			next = mws[i](pattern, next)
+		}
+
+		return next
+	}
+}
@@ -0,0 +1,253 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+// OpenAPIOptions настраивает OpenAPIValidator
+type OpenAPIOptions struct {
+	// ValidateResponses включает проверку ответов по схеме, расхождения логируются, а ответ
+	// отправляется без изменений. Ответ буферизуется целиком, поэтому опция для dev и staging.
+	ValidateResponses bool
+	// Logger получает расхождения ответов со схемой
+	Logger Logger
+}
+
+// OpenAPIValidator проверяет параметры пути, запроса, заголовки и тело запроса по спецификации.
+// Операция ищется по шаблону маршрута ("GET /users/{id}"), маршруты вне спецификации пропускаются.
+// Некорректный запрос получает 400 application/problem+json с ошибками отдельных полей.
+func OpenAPIValidator(spec *openapi3.T, opts OpenAPIOptions) (Middleware, error) {
+	if spec == nil || spec.Paths == nil {
+		return nil, errors.New("openapi validator: empty spec")
+	}
+
+	routes := make(map[string]*routers.Route)
+	for p, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			routes[strings.ToUpper(method)+" "+p] = &routers.Route{
+				Spec:      spec,
+				Path:      p,
+				PathItem:  item,
+				Method:    strings.ToUpper(method),
+				Operation: op,
+			}
+		}
+	}
+
+	filterOpts := &openapi3filter.Options{
+		MultiError: true,
+		// аутентификация проверяется отдельным middleware
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	filterOpts.WithCustomSchemaErrorFunc(schemaErrorMessage)
+
+	return func(pattern string, next http.Handler) http.Handler {
+		route, ok := routes[pattern]
+		if !ok {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams(r, route),
+				Route:      route,
+				Options:    filterOpts,
+			}
+
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				_ = requestProblem(err).Write(w, r)
+				return
+			}
+
+			if !opts.ValidateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := &bufferedResponse{header: make(http.Header)}
+			next.ServeHTTP(buf, r)
+
+			if err := validateResponse(r.Context(), input, buf); err != nil && opts.Logger != nil {
+				ctx := opts.Logger.WithFields(r.Context(), map[string]interface{}{
+					"openapi_operation": route.Operation.OperationID,
+					"openapi_route":     pattern,
+					"http_status":       buf.status,
+				})
+				opts.Logger.Error(ctx, fmt.Sprintf("response does not match OpenAPI spec: %s", err))
+			}
+
+			buf.flushTo(w)
+		})
+	}, nil
+}
+
+// pathParams берет значения параметров пути, уже разобранные http.ServeMux
+func pathParams(r *http.Request, route *routers.Route) map[string]string {
+	params := make(map[string]string)
+
+	for _, p := range route.PathItem.Parameters {
+		if p.Value != nil && p.Value.In == openapi3.ParameterInPath {
+			params[p.Value.Name] = r.PathValue(p.Value.Name)
+		}
+	}
+	for _, p := range route.Operation.Parameters {
+		if p.Value != nil && p.Value.In == openapi3.ParameterInPath {
+			params[p.Value.Name] = r.PathValue(p.Value.Name)
+		}
+	}
+
+	return params
+}
+
+func validateResponse(ctx context.Context, input *openapi3filter.RequestValidationInput, buf *bufferedResponse) error {
+	status := buf.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	opts := &openapi3filter.Options{
+		MultiError:            true,
+		IncludeResponseStatus: true,
+	}
+	opts.WithCustomSchemaErrorFunc(schemaErrorMessage)
+
+	out := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 status,
+		Header:                 buf.header,
+		Options:                opts,
+	}
+	out.SetBodyBytes(buf.body.Bytes())
+
+	return openapi3filter.ValidateResponse(ctx, out)
+}
+
+// schemaErrorMessage убирает из текста ошибки дамп схемы и значения, оставляя путь и причину
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	if pointer := err.JSONPointer(); len(pointer) > 0 {
+		return fmt.Sprintf("/%s: %s", strings.Join(pointer, "/"), err.Reason)
+	}
+
+	return err.Reason
+}
+
+// requestProblem превращает ошибки валидации в ответ с ошибками отдельных полей
+func requestProblem(err error) *problem.Problem {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return problem.New(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBytes.Limit))
+	}
+
+	var errs []error
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		errs = multi
+	} else {
+		errs = []error{err}
+	}
+
+	p := problem.New(http.StatusBadRequest, "request does not match the API schema")
+	for _, e := range errs {
+		p.Errors = append(p.Errors, fieldErrors(e)...)
+	}
+
+	return p
+}
+
+func fieldErrors(err error) []problem.FieldError {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return []problem.FieldError{{Field: "request", Message: err.Error()}}
+	}
+
+	field := "body"
+	if reqErr.Parameter != nil {
+		field = reqErr.Parameter.Name
+	}
+
+	// ошибки схемы тела раскладываются по полям: body.user.name
+	var schemaErrs []error
+	var multi openapi3.MultiError
+	if errors.As(reqErr.Err, &multi) {
+		schemaErrs = multi
+	} else if reqErr.Err != nil {
+		schemaErrs = []error{reqErr.Err}
+	}
+
+	var out []problem.FieldError
+	for _, e := range schemaErrs {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			continue
+		}
+
+		name := field
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			name += "." + strings.Join(pointer, ".")
+		}
+
+		out = append(out, problem.FieldError{Field: name, Message: schemaErr.Reason})
+	}
+
+	if len(out) == 0 {
+		msg := reqErr.Reason
+		if reqErr.Err != nil {
+			msg = reqErr.Err.Error()
+		}
+
+		out = append(out, problem.FieldError{Field: field, Message: msg})
+	}
+
+	return out
+}
+
+// bufferedResponse накапливает ответ обработчика, чтобы проверить его до отправки клиенту
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	if b.status == 0 {
+		b.status = code
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponse) flushTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+
+	w.WriteHeader(b.status)
+	_, _ = io.Copy(w, &b.body)
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/http/problem"
+	"github.com/siyoga/rollstory/pkg/ratelimit"
+)
+
+// KeyFunc возвращает ключ клиента для ограничения частоты запросов.
+// Пустой ключ пропускает запрос без ограничения.
+type KeyFunc func(r *http.Request) string
+
+// RateLimitOptions настраивает RateLimit
+type RateLimitOptions struct {
+	Store ratelimit.Store
+	// Key определяет, чей лимит расходует запрос, по умолчанию KeyByIP(ForwardedFor{})
+	Key KeyFunc
+	// Default - квота маршрутов, для которых нет записи в Routes. Пустая квота не ограничивает.
+	Default ratelimit.Quota
+	// Routes - квоты отдельных маршрутов по шаблону ("POST /auth/login")
+	Routes ratelimit.RouteQuotas
+	// Logger получает ошибки хранилища, при них запрос пропускается
+	Logger Logger
+}
+
+// RateLimit ограничивает частоту запросов по алгоритму token bucket. У каждого маршрута своя
+// корзина на ключ клиента. Ответ содержит заголовки RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset и RateLimit-Policy, превышение лимита получает 429 с Retry-After.
+// При недоступности хранилища запросы пропускаются, чтобы сбой лимитера не отключал API.
+func RateLimit(opts RateLimitOptions) Middleware {
+	if opts.Key == nil {
+		opts.Key = KeyByIP(ForwardedFor{})
+	}
+
+	return func(pattern string, next http.Handler) http.Handler {
+		quota := opts.Default
+		if routeQuota, ok := opts.Routes[pattern]; ok {
+			quota = routeQuota
+		}
+
+		if !quota.Enabled() || opts.Store == nil {
+			return next
+		}
+
+		policy := fmt.Sprintf("%d;w=%d", quota.Requests, int(math.Ceil(quota.Per.Seconds())))
+		if quota.Burst > 0 {
+			policy += fmt.Sprintf(";burst=%d", quota.Burst)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientKey := opts.Key(r)
+			if clientKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := pattern + "|" + clientKey
+
+			res, err := opts.Store.Take(r.Context(), key, quota)
+			if err != nil {
+				if opts.Logger != nil {
+					opts.Logger.Warning(opts.Logger.WithField(r.Context(), "http_route", pattern), fmt.Sprintf("rate limiter unavailable, request allowed: %s", err))
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+			h.Set("RateLimit-Policy", policy)
+
+			if !res.Allowed {
+				h.Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(res.RetryAfter))))
+
+				p := problem.New(http.StatusTooManyRequests, "rate limit exceeded, retry later")
+				p.Code = "rate_limited"
+				_ = p.Write(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ForwardedFor определяет, можно ли брать IP клиента из X-Forwarded-For
+type ForwardedFor struct {
+	// Trust включается только за прокси, который дописывает адрес клиента в X-Forwarded-For,
+	// иначе клиент подставит любой адрес
+	Trust bool
+	// TrustedProxies - сети своих прокси. Их адреса пропускаются при разборе цепочки справа
+	// налево, без них IP клиента - последний адрес цепочки.
+	TrustedProxies []netip.Prefix
+}
+
+// KeyByIP использует IP клиента
+func KeyByIP(fwd ForwardedFor) KeyFunc {
+	return func(r *http.Request) string {
+		return "ip:" + clientIP(r, fwd)
+	}
+}
+
+// KeyByHeader использует значение заголовка, например X-Client-Id, а без него - IP клиента
+func KeyByHeader(header string, fwd ForwardedFor) KeyFunc {
+	byIP := KeyByIP(fwd)
+
+	return func(r *http.Request) string {
+		if v := r.Header.Get(header); v != "" {
+			return strings.ToLower(header) + ":" + v
+		}
+
+		return byIP(r)
+	}
+}
+
+// KeyByUser использует идентификатор пользователя, установленный Authenticate, и не ограничивает
+// анонимные запросы: их ограничивает RateLimit с KeyByIP, который выполняется раньше Authenticate.
+// Authenticate должен выполняться раньше RateLimit с этим ключом.
+func KeyByUser() KeyFunc {
+	return func(r *http.Request) string {
+		if userID, ok := auth.UserIDFromContext(r.Context()); ok {
+			return "user:" + userID
+		}
+
+		return ""
+	}
+}
+
+// KeyByRoute - общий лимит маршрута на всех клиентов
+func KeyByRoute() KeyFunc {
+	return func(*http.Request) string {
+		return "route"
+	}
+}
+
+// clientIP разбирает X-Forwarded-For справа налево: левые адреса задает сам клиент, достоверен
+// только адрес, который дописал ближайший недоверенный узел
+func clientIP(r *http.Request, fwd ForwardedFor) string {
+	if fwd.Trust {
+		hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+
+			addr = addr.Unmap()
+			if !fwd.trusted(addr) {
+				return addr.String()
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func (f ForwardedFor) trusted(addr netip.Addr) bool {
+	for _, p := range f.TrustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
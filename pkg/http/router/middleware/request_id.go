@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/siyoga/rollstory/pkg/http/requestid"
+)
+
+// RequestID берет идентификатор запроса из X-Request-Id или генерирует новый, возвращает его
+// в ответе и кладет в контекст: в requestid.FromContext и в поле request_id логгера
+func RequestID(logger Logger) Middleware {
+	return func(_ string, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = requestid.New()
+			}
+
+			w.Header().Set(requestid.Header, id)
+
+			ctx := requestid.WithContext(r.Context(), id)
+			ctx = logger.WithField(ctx, "request_id", id)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/http/problem"
+)
+
+// Timeout ограничивает время обработки запроса: контекст запроса отменяется по истечении
+// timeout, для маршрутов из routes используется свой таймаут (0 - без ограничения).
+// Если обработчик вернулся по отмене контекста, ничего не записав, клиент получает 503.
+func Timeout(timeout time.Duration, routes map[string]time.Duration) Middleware {
+	return func(pattern string, next http.Handler) http.Handler {
+		d := timeout
+		if routeTimeout, ok := routes[pattern]; ok {
+			d = routeTimeout
+		}
+
+		if d <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			rec := newResponseRecorder(w)
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			if !rec.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				p := problem.New(http.StatusServiceUnavailable, "request timed out, retry later")
+				p.Code = "request_timeout"
+				_ = p.Write(rec, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import "net/http"
+
+// responseRecorder запоминает статус и размер ответа. Unwrap позволяет
+// http.ResponseController добраться до Flush, Hijack и дедлайнов исходного writer.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	if rec, ok := w.(*responseRecorder); ok {
+		return rec
+	}
+
+	return &responseRecorder{ResponseWriter: w}
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Status возвращает код ответа, 0 если ответ еще не начат
+func (r *responseRecorder) Status() int {
+	return r.status
+}
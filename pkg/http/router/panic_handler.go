@@ -1,24 +1,164 @@
 package router
 
 import (
-	"github.com/siyoga/rollstory/pkg/http/router/response"
+	"expvar"
+	"fmt"
 	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/siyoga/rollstory/pkg/http/requestid"
+	"github.com/siyoga/rollstory/pkg/http/router/response"
 )
 
+// panics - количество паник по шаблонам маршрутов, доступно в /debug/vars для алертов
+var panics = expvar.NewMap("http_panics")
+
+// maxStackFrames ограничивает глубину стека, попадающего в лог
+const maxStackFrames = 64
+
+// Panic описывает перехваченную панику обработчика
+type Panic struct {
+	Value any
+	// Stack - стек от места паники, без кадров рантайма
+	Stack []Frame
+	// Pattern - шаблон маршрута, в котором произошла паника
+	Pattern   string
+	RequestID string
+	// IncidentID возвращается клиенту и позволяет найти запись в логах
+	IncidentID string
+	// ResponseStarted - обработчик успел начать ответ, поэтому ответ с ошибкой отправить нельзя
+	ResponseStarted bool
+}
+
+// Frame - кадр стека
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+}
+
+func (p *Panic) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// StackStrings возвращает стек в виде строк для логирования
+func (p *Panic) StackStrings() []string {
+	out := make([]string, 0, len(p.Stack))
+	for _, f := range p.Stack {
+		out = append(out, f.String())
+	}
+
+	return out
+}
+
 func panicMiddleware(errHandler InternalErrHandler, panicHandler PanicHandler) func(string, http.Handler) http.Handler {
 	return func(pattern string, next http.Handler) http.Handler {
 		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
-			handlerResp := response.NewResponse()
+			tracker := &startTracker{ResponseWriter: resp}
+
 			defer func() {
-				if err := recover(); err != nil {
-					ctx := req.Context()
-					panicHandler(ctx, err)
-					errHandler(ctx, handlerResp)
-					_ = handlerResp.Send(resp)
+				err := recover()
+				if err == nil {
+					return
+				}
+
+				// обработчик намеренно оборвал ответ, net/http сам закроет соединение
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+
+				p := &Panic{
+					Value:           err,
+					Stack:           callers(),
+					Pattern:         pattern,
+					RequestID:       requestID(req, resp),
+					IncidentID:      uuid.NewString(),
+					ResponseStarted: tracker.started,
+				}
+
+				panics.Add(pattern, 1)
+				panicHandler(req.Context(), p)
+
+				// часть ответа уже у клиента: дописать ошибку нельзя, обрываем соединение,
+				// чтобы клиент не принял неполный ответ за успешный
+				if p.ResponseStarted {
+					panic(http.ErrAbortHandler)
 				}
+
+				handlerResp := response.NewResponse()
+				errHandler(req, p, handlerResp)
+				_ = handlerResp.Send(resp)
 			}()
 
-			next.ServeHTTP(resp, req)
+			next.ServeHTTP(tracker, req)
 		})
 	}
 }
+
+// requestID берет идентификатор из контекста или из заголовка ответа, который выставляет
+// middleware.RequestID: panicMiddleware выполняется раньше и не видит его контекст
+func requestID(req *http.Request, resp http.ResponseWriter) string {
+	if id := requestid.FromContext(req.Context()); id != "" {
+		return id
+	}
+
+	return resp.Header().Get(requestid.Header)
+}
+
+// callers собирает стек паникующей горутины, пропуская кадры рантайма и самого middleware
+func callers() []Frame {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []Frame
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "runtime.") {
+			stack = append(stack, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+// startTracker запоминает, начал ли обработчик отправлять ответ
+type startTracker struct {
+	http.ResponseWriter
+
+	started bool
+}
+
+func (t *startTracker) WriteHeader(code int) {
+	// информационные ответы 1xx не фиксируют статус
+	if code >= http.StatusOK {
+		t.started = true
+	}
+
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *startTracker) Write(p []byte) (int, error) {
+	t.started = true
+	return t.ResponseWriter.Write(p)
+}
+
+func (t *startTracker) Flush() {
+	t.started = true
+	_ = http.NewResponseController(t.ResponseWriter).Flush()
+}
+
+func (t *startTracker) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
@@ -1,3 +1 @@
 package request
-
-import "net/http"
@@ -12,6 +12,7 @@ type Request interface {
 	URLPath() string
 	Method() string
 	JSONBody(data interface{}) error
+	Cookie(name string) (*http.Cookie, bool)
 }
 
 type request struct {
@@ -79,6 +80,12 @@ func (r *request) Method() string {
 	return r.method
 }
 
+// Cookie возвращает cookie запроса по имени
+func (r *request) Cookie(name string) (*http.Cookie, bool) {
+	cookie, ok := r.cookies[name]
+	return cookie, ok
+}
+
 func (r *request) JSONBody(data interface{}) error {
 	if r.body == nil || data == nil {
 		return nil
@@ -93,10 +93,14 @@ func (r *response) Send(w http.ResponseWriter) error {
 		}
 	}
 
+	// заголовки после WriteHeader уже не отправляются, поэтому тип содержимого выставляется заранее
+	if r.JsonBody != nil && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
 	w.WriteHeader(r.Code)
 
 	if r.JsonBody != nil {
-		w.Header().Set("Content-Type", "application/json")
 		return json.NewEncoder(w).Encode(r.JsonBody)
 	}
 
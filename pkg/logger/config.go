@@ -0,0 +1,11 @@
+package logger
+
+// Config описывает настройки логгера, заполняется через env.Load
+type Config struct {
+	App           string   `env:"APP_NAME"`
+	Enabled       bool     `env:"LOGGER_ENABLED" default:"true"`
+	Output        string   `env:"LOGGER_OUTPUT" default:"stderr" oneof:"stdout,stderr"`
+	Format        string   `env:"LOGGER_FORMAT" default:"json" oneof:"json,text,pretty"`
+	Level         string   `env:"LOGGER_LEVEL" default:"INFO"`
+	IncludeFields []string `env:"LOGGER_INCLUDE_FIELDS"`
+}
@@ -17,12 +17,15 @@ type driver interface {
 	Warning(ctx context.Context, arg ...interface{})
 	Error(ctx context.Context, arg ...interface{})
 	Flush(timeout time.Duration) error
+	SetLevel(level string) error
+	Level() string
 }
 
 type zapDriver struct {
 	*options
 
-	zap *zap.Logger
+	zap   *zap.Logger
+	level zap.AtomicLevel
 }
 
 func newZapDriver(o *options) (*zapDriver, error) {
@@ -64,10 +67,12 @@ func newZapDriver(o *options) (*zapDriver, error) {
 		encoder = newLimitJSONSizeEncoder(encoder, o.maxLogEntrySize)
 	}
 
+	atomicLevel := zap.NewAtomicLevelAt(level)
+
 	core := zapcore.NewCore(
 		encoder,
 		zapcore.AddSync(o.output),
-		level,
+		atomicLevel,
 	)
 
 	if len(o.includedFields) > 0 {
@@ -84,6 +89,7 @@ func newZapDriver(o *options) (*zapDriver, error) {
 	return &zapDriver{
 		options: o,
 		zap:     zapLogger,
+		level:   atomicLevel,
 	}, nil
 }
 
@@ -138,6 +144,20 @@ func (d *zapDriver) Flush(timeout time.Duration) error {
 	return d.zap.Sync()
 }
 
+func (d *zapDriver) SetLevel(level string) error {
+	zapLevel, err := parseZapLevel(level)
+	if err != nil {
+		return err
+	}
+
+	d.level.SetLevel(zapLevel)
+	return nil
+}
+
+func (d *zapDriver) Level() string {
+	return d.level.Level().CapitalString()
+}
+
 const (
 	defSkipDepth = 3
 	maxSkipDepth = 12
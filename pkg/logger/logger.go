@@ -12,7 +12,10 @@ type Logger struct {
 }
 
 func New(opts ...Option) (*Logger, error) {
-	o := newOptions(opts...)
+	o, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
 
 	d, err := createDriver(o)
 	if err != nil {
@@ -128,3 +131,13 @@ func (l *Logger) Flush(timeout time.Duration) bool {
 
 	return true
 }
+
+// SetLevel меняет уровень логирования на лету, принимает те же значения, что и LOGGER_LEVEL
+func (l *Logger) SetLevel(level string) error {
+	return l.driver.SetLevel(level)
+}
+
+// Level возвращает текущий уровень логирования
+func (l *Logger) Level() string {
+	return l.driver.Level()
+}
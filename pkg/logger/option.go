@@ -1,6 +1,13 @@
 package logger
 
-import "io"
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/siyoga/rollstory/pkg/env"
+)
 
 type options struct {
 	output io.Writer
@@ -13,26 +20,58 @@ type options struct {
 	enabled         bool
 	format          string
 	maxLogEntrySize int
+
+	configured bool
 }
 
 type Option func(*options)
 
-func newOptions(opts ...Option) *options {
-	opt := &options{
-		output: envGetOutput(),
-
-		defaultFields:  make(map[string]interface{}),
-		includedFields: envStringArray(envIncludeFields, nil),
+// WithConfig применяет настройки из Config. Если опция не передана,
+// конфигурация читается из переменных окружения.
+func WithConfig(cfg Config) Option {
+	return func(o *options) {
+		o.output = configOutput(cfg.Output)
+		o.includedFields = cfg.IncludeFields
+		o.app = cfg.App
+		o.level = cfg.Level
+		o.enabled = cfg.Enabled
+		o.format = cfg.Format
+		o.configured = true
+	}
+}
 
-		app:     envString(envAppName, ""),
-		level:   envString(envLevel, defaultLevel),
-		enabled: envBool(envEnabled, true),
-		format:  envString(envFormat, defaultFormat),
+func newOptions(opts ...Option) (*options, error) {
+	opt := &options{
+		defaultFields: make(map[string]interface{}),
 	}
 
 	for _, o := range opts {
 		o(opt)
 	}
 
-	return opt
+	if !opt.configured {
+		cfg := Config{}
+		if err := env.Load(&cfg); err != nil {
+			return nil, fmt.Errorf("load logger config: %w", err)
+		}
+
+		// явно переданные опции имеют приоритет над окружением
+		opts = append([]Option{WithConfig(cfg)}, opts...)
+		for _, o := range opts {
+			o(opt)
+		}
+	}
+
+	return opt, nil
+}
+
+func configOutput(output string) *os.File {
+	switch strings.ToLower(output) {
+	case "stdout":
+		return os.Stdout
+	case "stderr":
+		fallthrough
+	default:
+		return os.Stderr
+	}
 }
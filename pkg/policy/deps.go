@@ -0,0 +1,9 @@
+package policy
+
+import "context"
+
+type Logger interface {
+	Info(ctx context.Context, args ...interface{})
+	Warning(ctx context.Context, args ...interface{})
+	WithFields(ctx context.Context, fields map[string]interface{}) context.Context
+}
@@ -0,0 +1,165 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/siyoga/rollstory/pkg/apperror"
+)
+
+// Engine хранит разрешения ролей и принимает решения по запросам
+type Engine struct {
+	grants map[Role][]Grant
+	logger Logger
+}
+
+type Option func(*Engine)
+
+// WithRole выдает роли разрешения, повторный вызов для той же роли добавляет новые
+func WithRole(role Role, grants ...[]Grant) Option {
+	return func(e *Engine) {
+		for _, g := range grants {
+			e.grants[role] = append(e.grants[role], g...)
+		}
+	}
+}
+
+// WithLogger включает аудит: каждое решение записывается в лог, отказ - с уровнем warning
+func WithLogger(logger Logger) Option {
+	return func(e *Engine) {
+		e.logger = logger
+	}
+}
+
+func New(opts ...Option) *Engine {
+	e := &Engine{
+		grants: make(map[Role][]Grant),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Evaluate принимает решение по запросу. Разрешения на любые ресурсы проверяются раньше
+// разрешений на собственные, поэтому в аудит попадает самое широкое основание доступа.
+func (e *Engine) Evaluate(ctx context.Context, req Request) Decision {
+	d := e.decide(req)
+	e.audit(ctx, req, d)
+
+	return d
+}
+
+// Authorize возвращает apperror с видом Forbidden, если запрос не разрешен
+func (e *Engine) Authorize(ctx context.Context, req Request) error {
+	d := e.Evaluate(ctx, req)
+	if d.Allowed {
+		return nil
+	}
+
+	return apperror.Forbidden(
+		"forbidden",
+		fmt.Sprintf("missing permission %s", req.Permission),
+		apperror.WithField("policy_permission", string(req.Permission)),
+		apperror.WithField("policy_reason", d.Reason),
+	)
+}
+
+func (e *Engine) decide(req Request) Decision {
+	if req.Subject.ID == "" {
+		return Decision{Reason: "anonymous subject"}
+	}
+
+	if req.Permission == "" {
+		return Decision{Reason: "empty permission"}
+	}
+
+	if req.Subject.Scopes != nil && !inScopes(req.Subject.Scopes, req.Permission) {
+		return Decision{Reason: "outside granted scopes"}
+	}
+
+	for _, role := range req.Subject.Roles {
+		for _, g := range e.grants[role] {
+			if !g.OwnerOnly && g.Permission.Matches(req.Permission) {
+				return Decision{Allowed: true, Reason: "role " + string(role)}
+			}
+		}
+	}
+
+	// владение проверяется только для ресурса того вида, на который запрошено разрешение
+	if req.Resource != nil && req.Resource.Type != req.Permission.Resource() {
+		return Decision{Reason: "resource type doesn't match permission"}
+	}
+
+	for _, role := range req.Subject.Roles {
+		for _, g := range e.grants[role] {
+			if !g.OwnerOnly || !g.Permission.Matches(req.Permission) {
+				continue
+			}
+
+			if req.Resource == nil {
+				return Decision{Allowed: true, Reason: "role " + string(role) + " on own resources", RequiresOwnership: true}
+			}
+
+			if req.Resource.OwnerID != "" && req.Resource.OwnerID == req.Subject.ID {
+				return Decision{Allowed: true, Reason: "role " + string(role) + " as resource owner"}
+			}
+		}
+	}
+
+	// запрещено все, что не разрешено явно
+	return Decision{Reason: "no matching grant"}
+}
+
+func inScopes(scopes []Permission, required Permission) bool {
+	for _, s := range scopes {
+		if s.Matches(required) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *Engine) audit(ctx context.Context, req Request, d Decision) {
+	if e.logger == nil {
+		return
+	}
+
+	roles := make([]string, 0, len(req.Subject.Roles))
+	for _, r := range req.Subject.Roles {
+		roles = append(roles, string(r))
+	}
+
+	decision := "deny"
+	if d.Allowed {
+		decision = "allow"
+	}
+
+	fields := map[string]interface{}{
+		"policy_subject":    req.Subject.ID,
+		"policy_roles":      strings.Join(roles, ","),
+		"policy_permission": string(req.Permission),
+		"policy_resource":   req.Resource.String(),
+		"policy_decision":   decision,
+		"policy_reason":     d.Reason,
+	}
+	if req.Subject.Scopes != nil {
+		scopes := make([]string, 0, len(req.Subject.Scopes))
+		for _, s := range req.Subject.Scopes {
+			scopes = append(scopes, string(s))
+		}
+		fields["policy_scopes"] = strings.Join(scopes, ",")
+	}
+
+	ctx = e.logger.WithFields(ctx, fields)
+
+	if d.Allowed {
+		e.logger.Info(ctx, "Access granted")
+	} else {
+		e.logger.Warning(ctx, "Access denied")
+	}
+}
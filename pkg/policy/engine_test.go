@@ -0,0 +1,194 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/siyoga/rollstory/pkg/apperror"
+)
+
+func TestPermissionMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  Permission
+		required Permission
+		want     bool
+	}{
+		{name: "exact", granted: "campaign:read", required: "campaign:read", want: true},
+		{name: "other action", granted: "campaign:read", required: "campaign:write", want: false},
+		{name: "other resource", granted: "campaign:read", required: "session:read", want: false},
+		{name: "everything", granted: "*", required: "campaign:write", want: true},
+		{name: "any action", granted: "campaign:*", required: "campaign:delete", want: true},
+		{name: "any action of other resource", granted: "campaign:*", required: "session:read", want: false},
+		{name: "any resource", granted: "*:read", required: "session:read", want: true},
+		{name: "any resource other action", granted: "*:read", required: "session:write", want: false},
+		{name: "malformed grant", granted: "campaign", required: "campaign:read", want: false},
+		{name: "malformed required", granted: "campaign:*", required: "campaign", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.granted.Matches(tt.required); got != tt.want {
+				t.Errorf("%q.Matches(%q) = %v, want %v", tt.granted, tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	engine := New(
+		WithRole(RoleGameMaster, Allow("campaign:*")),
+		WithRole(RolePlayer,
+			Allow("campaign:read"),
+			AllowOwn("character:*"),
+		),
+	)
+
+	player := Subject{ID: "u1", Roles: []Role{RolePlayer}}
+
+	tests := []struct {
+		name    string
+		req     Request
+		want    Decision
+		wantErr bool
+	}{
+		{
+			name: "anonymous subject",
+			req:  Request{Subject: Subject{Roles: []Role{RoleGameMaster}}, Permission: "campaign:read"},
+			want: Decision{Reason: "anonymous subject"},
+		},
+		{
+			name: "empty permission",
+			req:  Request{Subject: player},
+			want: Decision{Reason: "empty permission"},
+		},
+		{
+			name: "role grant",
+			req:  Request{Subject: player, Permission: "campaign:read"},
+			want: Decision{Allowed: true, Reason: "role player"},
+		},
+		{
+			name: "wildcard role grant",
+			req:  Request{Subject: Subject{ID: "u2", Roles: []Role{RoleGameMaster}}, Permission: "campaign:delete"},
+			want: Decision{Allowed: true, Reason: "role game_master"},
+		},
+		{
+			name: "nil scopes leave role grants as is",
+			req:  Request{Subject: Subject{ID: "u1", Roles: []Role{RolePlayer}, Scopes: nil}, Permission: "campaign:read"},
+			want: Decision{Allowed: true, Reason: "role player"},
+		},
+		{
+			name: "empty scopes deny everything",
+			req:  Request{Subject: Subject{ID: "u1", Roles: []Role{RolePlayer}, Scopes: []Permission{}}, Permission: "campaign:read"},
+			want: Decision{Reason: "outside granted scopes"},
+		},
+		{
+			name: "scope narrows role grant",
+			req: Request{
+				Subject:    Subject{ID: "u2", Roles: []Role{RoleGameMaster}, Scopes: []Permission{"campaign:read"}},
+				Permission: "campaign:write",
+			},
+			want: Decision{Reason: "outside granted scopes"},
+		},
+		{
+			name: "scope doesn't widen role grant",
+			req: Request{
+				Subject:    Subject{ID: "u1", Roles: []Role{RolePlayer}, Scopes: []Permission{"*"}},
+				Permission: "campaign:write",
+			},
+			want: Decision{Reason: "no matching grant"},
+		},
+		{
+			name: "own grant without resource",
+			req:  Request{Subject: player, Permission: "character:write"},
+			want: Decision{Allowed: true, Reason: "role player on own resources", RequiresOwnership: true},
+		},
+		{
+			name: "own grant for owner",
+			req: Request{
+				Subject:    player,
+				Permission: "character:write",
+				Resource:   &Resource{Type: "character", ID: "c1", OwnerID: "u1"},
+			},
+			want: Decision{Allowed: true, Reason: "role player as resource owner"},
+		},
+		{
+			name: "own grant for another owner",
+			req: Request{
+				Subject:    player,
+				Permission: "character:write",
+				Resource:   &Resource{Type: "character", ID: "c2", OwnerID: "u2"},
+			},
+			want:    Decision{Reason: "no matching grant"},
+			wantErr: true,
+		},
+		{
+			name: "own grant for resource without owner",
+			req: Request{
+				Subject:    player,
+				Permission: "character:write",
+				Resource:   &Resource{Type: "character", ID: "c3"},
+			},
+			want:    Decision{Reason: "no matching grant"},
+			wantErr: true,
+		},
+		{
+			name: "own grant for owned resource of another type",
+			req: Request{
+				Subject:    player,
+				Permission: "character:write",
+				Resource:   &Resource{Type: "campaign", ID: "k1", OwnerID: "u1"},
+			},
+			want:    Decision{Reason: "resource type doesn't match permission"},
+			wantErr: true,
+		},
+		{
+			name: "own grant for resource without type",
+			req: Request{
+				Subject:    player,
+				Permission: "character:write",
+				Resource:   &Resource{ID: "c1", OwnerID: "u1"},
+			},
+			want:    Decision{Reason: "resource type doesn't match permission"},
+			wantErr: true,
+		},
+		{
+			name:    "no role",
+			req:     Request{Subject: Subject{ID: "u3"}, Permission: "campaign:read"},
+			want:    Decision{Reason: "no matching grant"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown role",
+			req:     Request{Subject: Subject{ID: "u3", Roles: []Role{"admin"}}, Permission: "campaign:read"},
+			want:    Decision{Reason: "no matching grant"},
+			wantErr: true,
+		},
+		{
+			name:    "not granted",
+			req:     Request{Subject: player, Permission: "session:read"},
+			want:    Decision{Reason: "no matching grant"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.Evaluate(context.Background(), tt.req); got != tt.want {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+
+			err := engine.Authorize(context.Background(), tt.req)
+			if tt.want.Allowed && err != nil {
+				t.Errorf("Authorize() = %v, want nil", err)
+			}
+			if tt.wantErr {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) || appErr.Kind != apperror.KindForbidden {
+					t.Errorf("Authorize() = %v, want forbidden", err)
+				}
+			}
+		})
+	}
+}
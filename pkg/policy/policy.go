@@ -0,0 +1,114 @@
+// Package policy решает, может ли пользователь выполнить действие: разрешения выдаются ролям,
+// часть из них действует только на ресурсы, которыми пользователь владеет. Все, что явно
+// не разрешено, запрещено.
+package policy
+
+import "strings"
+
+// Role - роль пользователя
+type Role string
+
+const (
+	RoleGameMaster Role = "game_master"
+	RolePlayer     Role = "player"
+	RoleSpectator  Role = "spectator"
+)
+
+// Permission - действие над видом ресурса в формате "<ресурс>:<действие>", например "campaign:write".
+// В выданных разрешениях "*" заменяет ресурс или действие целиком: "campaign:*", "*".
+type Permission string
+
+// Matches сообщает, покрывает ли выданное разрешение p требуемое required
+func (p Permission) Matches(required Permission) bool {
+	if p == "*" || p == required {
+		return true
+	}
+
+	resource, action, ok := strings.Cut(string(p), ":")
+	if !ok {
+		return false
+	}
+
+	reqResource, reqAction, ok := strings.Cut(string(required), ":")
+	if !ok {
+		return false
+	}
+
+	return (resource == "*" || resource == reqResource) && (action == "*" || action == reqAction)
+}
+
+// Resource возвращает вид ресурса разрешения, например "campaign" для "campaign:write"
+func (p Permission) Resource() string {
+	resource, _, _ := strings.Cut(string(p), ":")
+	return resource
+}
+
+// Grant - разрешение роли. OwnerOnly ограничивает его ресурсами, владелец которых - сам пользователь.
+type Grant struct {
+	Permission Permission
+	OwnerOnly  bool
+}
+
+// Allow выдает разрешения на любые ресурсы
+func Allow(permissions ...Permission) []Grant {
+	grants := make([]Grant, 0, len(permissions))
+	for _, p := range permissions {
+		grants = append(grants, Grant{Permission: p})
+	}
+
+	return grants
+}
+
+// AllowOwn выдает разрешения только на собственные ресурсы
+func AllowOwn(permissions ...Permission) []Grant {
+	grants := make([]Grant, 0, len(permissions))
+	for _, p := range permissions {
+		grants = append(grants, Grant{Permission: p, OwnerOnly: true})
+	}
+
+	return grants
+}
+
+// Subject - пользователь, для которого принимается решение
+type Subject struct {
+	ID    string
+	Roles []Role
+	// Scopes ограничивает разрешения ролей, например областями API ключа. nil - без ограничений,
+	// пустой срез запрещает все.
+	Scopes []Permission
+}
+
+// Resource - ресурс, к которому запрашивается доступ
+type Resource struct {
+	Type    string
+	ID      string
+	OwnerID string
+}
+
+func (r *Resource) String() string {
+	if r == nil {
+		return ""
+	}
+
+	return r.Type + "/" + r.ID
+}
+
+// Request - запрос на выполнение действия. Без Resource проверяется только, что у пользователя
+// есть разрешение в принципе (на уровне операции API), владение ресурсом проверяет обработчик.
+// Resource.Type должен совпадать с видом ресурса в Permission, иначе владение ресурсом
+// одного вида давало бы доступ к ресурсам другого.
+type Request struct {
+	Subject    Subject
+	Permission Permission
+	Resource   *Resource
+}
+
+// Decision - результат проверки
+type Decision struct {
+	Allowed bool
+	// Reason объясняет решение для аудита: роль или владение, давшие доступ, либо причина отказа
+	Reason string
+	// RequiresOwnership - доступ дан разрешением OwnerOnly без указания ресурса,
+	// обработчик должен повторить проверку с ресурсом
+	RequiresOwnership bool
+}
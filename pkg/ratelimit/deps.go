@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DB реализуется *sqlx.DB и соединениями из internal/inf/postgres/public
+type DB interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Logger interface {
+	Warning(ctx context.Context, args ...interface{})
+}
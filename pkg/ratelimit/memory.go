@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/maphash"
+	"sync"
+	"time"
+)
+
+const (
+	memoryShards = 64
+	// defaultCleanupInterval - как часто удаляются заполненные корзины, они не отличаются от отсутствующих
+	defaultCleanupInterval = time.Minute
+)
+
+// MemoryStore хранит корзины в памяти процесса. Подходит для одного инстанса,
+// при нескольких инстансах каждый считает лимиты независимо.
+type MemoryStore struct {
+	seed   maphash.Seed
+	shards [memoryShards]memoryShard
+
+	cleanupInterval time.Duration
+	now             func() time.Time
+}
+
+type memoryShard struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// full - момент, когда корзина заполнится, после него ее можно удалить
+	full time.Time
+}
+
+type MemoryOption func(*MemoryStore)
+
+// WithMemoryCleanupInterval задает периодичность удаления неактивных корзин
+func WithMemoryCleanupInterval(interval time.Duration) MemoryOption {
+	return func(s *MemoryStore) {
+		if interval > 0 {
+			s.cleanupInterval = interval
+		}
+	}
+}
+
+func NewMemoryStore(opts ...MemoryOption) *MemoryStore {
+	s := &MemoryStore{
+		seed:            maphash.MakeSeed(),
+		cleanupInterval: defaultCleanupInterval,
+		now:             time.Now,
+	}
+
+	for i := range s.shards {
+		s.shards[i].buckets = make(map[string]*bucket)
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, q Quota) (Result, error) {
+	now := s.now()
+	shard := &s.shards[maphash.String(s.seed, key)%memoryShards]
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if now.Sub(shard.lastCleanup) >= s.cleanupInterval {
+		shard.cleanup(now)
+	}
+
+	capacity := float64(q.Capacity())
+	rate := q.Rate()
+
+	b, ok := shard.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updatedAt: now}
+		shard.buckets[key] = b
+	}
+
+	b.tokens = min(capacity, b.tokens+now.Sub(b.updatedAt).Seconds()*rate)
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	b.full = now.Add(secondsToDuration((capacity - b.tokens) / rate))
+
+	return result(q, allowed, b.tokens), nil
+}
+
+func (sh *memoryShard) cleanup(now time.Time) {
+	for key, b := range sh.buckets {
+		if !now.Before(b.full) {
+			delete(sh.buckets, key)
+		}
+	}
+
+	sh.lastCleanup = now
+}
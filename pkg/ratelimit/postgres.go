@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+)
+
+// Корзины хранятся в таблице rate_limit_buckets (см. миграции сервиса):
+//
+//	key text PRIMARY KEY, tokens double precision, allowed boolean,
+//	updated_at timestamptz, expires_at timestamptz
+//
+// Пополнение и списание выполняются одним upsert по времени БД, поэтому лимит общий
+// для всех инстансов и не зависит от расхождения их часов.
+const (
+	refillExpr = `LEAST($2::float8, b.tokens + GREATEST(0, EXTRACT(EPOCH FROM EXCLUDED.updated_at - b.updated_at)) * $3::float8)`
+
+	takeQuery = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at, expires_at)
+VALUES ($1, $2::float8 - 1, true, clock_timestamp(), clock_timestamp() + make_interval(secs => $2::float8 / $3::float8))
+ON CONFLICT (key) DO UPDATE SET
+    tokens = CASE WHEN ` + refillExpr + ` >= 1 THEN ` + refillExpr + ` - 1 ELSE ` + refillExpr + ` END,
+    allowed = ` + refillExpr + ` >= 1,
+    updated_at = EXCLUDED.updated_at,
+    expires_at = EXCLUDED.expires_at
+RETURNING tokens, allowed`
+
+	cleanupQuery = `DELETE FROM rate_limit_buckets WHERE expires_at < clock_timestamp()`
+)
+
+// PostgresStore хранит корзины в Postgres, лимиты общие для всех инстансов сервиса
+type PostgresStore struct {
+	db              DB
+	logger          Logger
+	cleanupInterval time.Duration
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+type PostgresOption func(*PostgresStore)
+
+// WithPostgresCleanupInterval задает периодичность удаления заполненных корзин
+func WithPostgresCleanupInterval(interval time.Duration) PostgresOption {
+	return func(s *PostgresStore) {
+		if interval > 0 {
+			s.cleanupInterval = interval
+		}
+	}
+}
+
+// WithPostgresLogger задает логгер для ошибок фоновой очистки
+func WithPostgresLogger(logger Logger) PostgresOption {
+	return func(s *PostgresStore) {
+		s.logger = logger
+	}
+}
+
+func NewPostgresStore(db DB, opts ...PostgresOption) *PostgresStore {
+	s := &PostgresStore{
+		db:              db,
+		cleanupInterval: defaultCleanupInterval,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+type bucketRow struct {
+	Tokens  float64 `db:"tokens"`
+	Allowed bool    `db:"allowed"`
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, q Quota) (Result, error) {
+	row, err := postgres.Get[bucketRow](ctx, s.db, takeQuery, key, float64(q.Capacity()), q.Rate())
+	if err != nil {
+		return Result{}, fmt.Errorf("take rate limit token: %w", err)
+	}
+
+	return result(q, row.Allowed, row.Tokens), nil
+}
+
+// Cleanup удаляет корзины, которые уже заполнились и не отличаются от отсутствующих
+func (s *PostgresStore) Cleanup(ctx context.Context) (int64, error) {
+	n, err := postgres.Exec(ctx, s.db, cleanupQuery)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup rate limit buckets: %w", err)
+	}
+
+	return n, nil
+}
+
+// Start запускает фоновую очистку таблицы
+func (s *PostgresStore) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run(s.stop, s.done)
+}
+
+// Stop останавливает фоновую очистку и дожидается завершения текущей
+func (s *PostgresStore) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+func (s *PostgresStore) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.cleanupInterval)
+		if _, err := s.Cleanup(ctx); err != nil && s.logger != nil {
+			s.logger.Warning(ctx, err.Error())
+		}
+		cancel()
+	}
+}
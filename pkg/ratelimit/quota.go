@@ -0,0 +1,162 @@
+// Package ratelimit реализует ограничение частоты запросов по алгоритму token bucket
+// с хранением состояния в памяти процесса или в Postgres
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quota - Requests запросов за Per. Burst - емкость корзины, сколько запросов можно
+// выполнить подряд после простоя, по умолчанию равна Requests.
+type Quota struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// ParseQuota разбирает квоту вида "100/1m" или "100/1m,20", где 20 - Burst.
+// Значение off дает пустую квоту, которая не ограничивает запросы.
+func ParseQuota(s string) (Quota, error) {
+	var q Quota
+
+	if strings.EqualFold(strings.TrimSpace(s), "off") {
+		return q, nil
+	}
+
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(s), ",")
+
+	requests, per, ok := strings.Cut(rate, "/")
+	if !ok {
+		return q, fmt.Errorf("invalid quota %q: expected <requests>/<duration>", s)
+	}
+
+	var err error
+	if q.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil {
+		return q, fmt.Errorf("invalid quota %q: %w", s, err)
+	}
+	if q.Per, err = time.ParseDuration(strings.TrimSpace(per)); err != nil {
+		return q, fmt.Errorf("invalid quota %q: %w", s, err)
+	}
+	if hasBurst {
+		if q.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil {
+			return q, fmt.Errorf("invalid quota %q: %w", s, err)
+		}
+	}
+
+	if q.Requests <= 0 || q.Per <= 0 || q.Burst < 0 {
+		return q, fmt.Errorf("invalid quota %q: values must be positive", s)
+	}
+
+	return q, nil
+}
+
+func (q *Quota) UnmarshalText(text []byte) error {
+	parsed, err := ParseQuota(string(text))
+	if err != nil {
+		return err
+	}
+
+	*q = parsed
+	return nil
+}
+
+func (q Quota) String() string {
+	if q.Burst > 0 && q.Burst != q.Requests {
+		return fmt.Sprintf("%d/%s,%d", q.Requests, q.Per, q.Burst)
+	}
+
+	return fmt.Sprintf("%d/%s", q.Requests, q.Per)
+}
+
+// Capacity - емкость корзины
+func (q Quota) Capacity() int {
+	if q.Burst > 0 {
+		return q.Burst
+	}
+
+	return q.Requests
+}
+
+// Rate - скорость пополнения корзины в токенах в секунду
+func (q Quota) Rate() float64 {
+	return float64(q.Requests) / q.Per.Seconds()
+}
+
+// Enabled - квота задана и ограничивает запросы
+func (q Quota) Enabled() bool {
+	return q.Requests > 0 && q.Per > 0
+}
+
+// RouteQuotas - квоты для отдельных маршрутов, из конфигурации разбирается из строки
+// вида "POST /auth/login=5/1m;GET /games=100/1m,20"
+type RouteQuotas map[string]Quota
+
+func (rq *RouteQuotas) UnmarshalText(text []byte) error {
+	out := make(RouteQuotas)
+
+	for _, item := range strings.Split(string(text), ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		i := strings.LastIndex(item, "=")
+		if i <= 0 {
+			return fmt.Errorf("invalid route quota %q: expected <pattern>=<quota>", item)
+		}
+
+		q, err := ParseQuota(item[i+1:])
+		if err != nil {
+			return err
+		}
+
+		out[strings.TrimSpace(item[:i])] = q
+	}
+
+	*rq = out
+	return nil
+}
+
+// Result - результат попытки взять токен
+type Result struct {
+	Allowed bool
+	// Limit - емкость корзины
+	Limit int
+	// Remaining - сколько запросов еще можно выполнить сейчас
+	Remaining int
+	// Reset - через сколько корзина снова заполнится полностью
+	Reset time.Duration
+	// RetryAfter - через сколько появится следующий токен, если запрос отклонен
+	RetryAfter time.Duration
+}
+
+// result вычисляет Result по количеству токенов после попытки
+func result(q Quota, allowed bool, tokens float64) Result {
+	capacity := q.Capacity()
+	rate := q.Rate()
+
+	res := Result{
+		Allowed:   allowed,
+		Limit:     capacity,
+		Remaining: max(0, int(math.Floor(tokens))),
+		Reset:     secondsToDuration((float64(capacity) - tokens) / rate),
+	}
+
+	if !allowed {
+		res.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	return res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
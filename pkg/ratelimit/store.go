@@ -0,0 +1,9 @@
+package ratelimit
+
+import "context"
+
+// Store хранит состояние корзин. Take атомарно пополняет корзину по прошедшему времени
+// и забирает токен, если он есть.
+type Store interface {
+	Take(ctx context.Context, key string, q Quota) (Result, error)
+}
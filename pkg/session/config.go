@@ -0,0 +1,42 @@
+package session
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/auth"
+)
+
+// Config описывает настройки сессий браузерных клиентов, заполняется через env.Load
+type Config struct {
+	// EncryptionKey шифрует и подписывает значение cookie (32 байта в base64)
+	EncryptionKey auth.SecretKey `env:"SESSION_ENCRYPTION_KEY" required:"true"`
+	// PreviousEncryptionKeys - прежние ключи, cookie с ними принимаются, нужны для ротации
+	PreviousEncryptionKeys []auth.SecretKey `env:"SESSION_PREVIOUS_ENCRYPTION_KEYS"`
+
+	// TTL - абсолютное время жизни сессии с момента входа
+	TTL time.Duration `env:"SESSION_TTL" default:"168h"`
+	// IdleTimeout завершает сессию, которой не пользовались дольше указанного, 0 отключает
+	IdleTimeout     time.Duration `env:"SESSION_IDLE_TIMEOUT" default:"24h"`
+	CleanupInterval time.Duration `env:"SESSION_CLEANUP_INTERVAL" default:"10m"`
+
+	CookieName     string `env:"SESSION_COOKIE_NAME" default:"rollstory_session"`
+	CookieDomain   string `env:"SESSION_COOKIE_DOMAIN"`
+	CookiePath     string `env:"SESSION_COOKIE_PATH" default:"/"`
+	CookieSecure   bool   `env:"SESSION_COOKIE_SECURE" default:"true"`
+	CookieHTTPOnly bool   `env:"SESSION_COOKIE_HTTP_ONLY" default:"true"`
+	CookieSameSite string `env:"SESSION_COOKIE_SAME_SITE" default:"lax" oneof:"lax,strict,none"`
+}
+
+// SameSite переводит CookieSameSite в значение net/http
+func (c Config) SameSite() http.SameSite {
+	switch strings.ToLower(c.CookieSameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
@@ -0,0 +1,21 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// Store хранит сессии, реализуется psql.SessionRepository. Get для отсутствующей
+// сессии возвращает postgres.ErrNotFound.
+type Store interface {
+	Create(ctx context.Context, s Session) error
+	Get(ctx context.Context, id string) (*Session, error)
+	Touch(ctx context.Context, id string, seenAt time.Time) error
+	Delete(ctx context.Context, id string) error
+	// DeleteExpired удаляет сессии, истекшие к now или простаивающие дольше idleTimeout (0 - без ограничения)
+	DeleteExpired(ctx context.Context, now time.Time, idleTimeout time.Duration) (int64, error)
+}
+
+type Logger interface {
+	Warning(ctx context.Context, args ...interface{})
+}
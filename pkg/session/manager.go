@@ -0,0 +1,290 @@
+package session
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siyoga/rollstory/pkg/auth"
+	"github.com/siyoga/rollstory/pkg/db/postgres"
+)
+
+const (
+	// touchInterval ограничивает запись времени последнего обращения, чтобы каждый запрос не обновлял строку
+	touchInterval = time.Minute
+
+	defaultCleanupInterval = 10 * time.Minute
+
+	// hostPrefix требует Secure, Path=/ и запрещает Domain, браузер отвергнет cookie без них
+	hostPrefix = "__Host-"
+)
+
+// Manager создает, загружает и завершает сессии и собирает для них cookie
+type Manager struct {
+	store  Store
+	cfg    Config
+	logger Logger
+	now    func() time.Time
+
+	// sealers[0] шифрует новые cookie, остальные только открывают cookie, выданные до ротации ключа
+	sealers []*auth.Sealer
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+type Option func(*Manager)
+
+// WithLogger задает логгер для ошибок фоновой очистки и обновления сессий
+func WithLogger(logger Logger) Option {
+	return func(m *Manager) {
+		m.logger = logger
+	}
+}
+
+func NewManager(store Store, cfg Config, opts ...Option) (*Manager, error) {
+	if cfg.CookieName == "" {
+		return nil, errors.New("session cookie name is empty")
+	}
+
+	if cfg.TTL <= 0 {
+		return nil, errors.New("session ttl must be positive")
+	}
+
+	if cfg.SameSite() == http.SameSiteNoneMode && !cfg.CookieSecure {
+		return nil, errors.New("session cookie with SameSite=None must be secure")
+	}
+
+	if strings.HasPrefix(cfg.CookieName, hostPrefix) && (!cfg.CookieSecure || cfg.CookiePath != "/" || cfg.CookieDomain != "") {
+		return nil, fmt.Errorf("session cookie %s must be secure, have path / and no domain", cfg.CookieName)
+	}
+
+	if cfg.CleanupInterval <= 0 {
+		cfg.CleanupInterval = defaultCleanupInterval
+	}
+
+	m := &Manager{
+		store: store,
+		cfg:   cfg,
+		now:   time.Now,
+	}
+
+	for i, key := range append([]auth.SecretKey{cfg.EncryptionKey}, cfg.PreviousEncryptionKeys...) {
+		sealer, err := auth.NewSealer(key)
+		if err != nil {
+			return nil, fmt.Errorf("session encryption key %d: %w", i, err)
+		}
+
+		m.sealers = append(m.sealers, sealer)
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m, nil
+}
+
+// Create начинает сессию пользователя и возвращает cookie, который нужно отправить клиенту
+func (m *Manager) Create(ctx context.Context, userID string) (*Session, http.Cookie, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, http.Cookie{}, err
+	}
+
+	csrf, err := newCSRFToken()
+	if err != nil {
+		return nil, http.Cookie{}, err
+	}
+
+	now := m.now()
+	s := &Session{
+		ID:         sessionID(token),
+		UserID:     userID,
+		CSRFToken:  csrf,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(m.cfg.TTL),
+	}
+
+	if err := m.store.Create(ctx, *s); err != nil {
+		return nil, http.Cookie{}, fmt.Errorf("store session: %w", err)
+	}
+
+	// имя cookie привязано к шифртексту, значение нельзя переставить в другой cookie
+	sealed, err := m.sealers[0].Seal(token, []byte(m.cfg.CookieName))
+	if err != nil {
+		return nil, http.Cookie{}, fmt.Errorf("seal session token: %w", err)
+	}
+
+	cookie := m.cookie(base64.RawURLEncoding.EncodeToString(sealed))
+	cookie.Expires = s.ExpiresAt
+	cookie.MaxAge = int(m.cfg.TTL.Seconds())
+
+	return s, cookie, nil
+}
+
+// Load возвращает сессию из cookie запроса. ErrNoSession - cookie нет, ErrInvalidSession -
+// cookie не открывается или сессия завершена, остальные ошибки - ошибки хранилища.
+func (m *Manager) Load(ctx context.Context, r *http.Request) (*Session, error) {
+	cookie, err := r.Cookie(m.cfg.CookieName)
+	if err != nil || cookie.Value == "" {
+		return nil, ErrNoSession
+	}
+
+	token, ok := m.open(cookie.Value)
+	if !ok {
+		return nil, ErrInvalidSession
+	}
+
+	s, err := m.store.Get(ctx, sessionID(token))
+	if errors.Is(err, postgres.ErrNotFound) {
+		return nil, ErrInvalidSession
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+
+	now := m.now()
+	if !s.ExpiresAt.After(now) || m.idle(s, now) {
+		// очистка удалит сессию позже, но завершенная сессия не должна дожидаться ее
+		if err := m.store.Delete(ctx, s.ID); err != nil {
+			m.warning(ctx, fmt.Sprintf("delete expired session: %s", err))
+		}
+
+		return nil, ErrInvalidSession
+	}
+
+	if now.Sub(s.LastSeenAt) >= touchInterval {
+		// время обращения нужно только для IdleTimeout, ошибка записи не должна отклонять запрос
+		if err := m.store.Touch(ctx, s.ID, now); err != nil {
+			m.warning(ctx, fmt.Sprintf("touch session: %s", err))
+		} else {
+			s.LastSeenAt = now
+		}
+	}
+
+	return s, nil
+}
+
+// Destroy завершает сессию и возвращает cookie, который удаляет ее у клиента
+func (m *Manager) Destroy(ctx context.Context, id string) (http.Cookie, error) {
+	if err := m.store.Delete(ctx, id); err != nil && !errors.Is(err, postgres.ErrNotFound) {
+		return http.Cookie{}, fmt.Errorf("delete session: %w", err)
+	}
+
+	return m.ClearCookie(), nil
+}
+
+// ClearCookie возвращает cookie, который удаляет cookie сессии у клиента
+func (m *Manager) ClearCookie() http.Cookie {
+	cookie := m.cookie("")
+	cookie.Expires = time.Unix(0, 0)
+	cookie.MaxAge = -1
+
+	return cookie
+}
+
+// Cleanup удаляет истекшие и простаивающие сессии
+func (m *Manager) Cleanup(ctx context.Context) (int64, error) {
+	n, err := m.store.DeleteExpired(ctx, m.now(), m.cfg.IdleTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("cleanup sessions: %w", err)
+	}
+
+	return n, nil
+}
+
+// Start запускает фоновую очистку хранилища
+func (m *Manager) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop != nil {
+		return
+	}
+
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go m.run(m.stop, m.done)
+}
+
+// Stop останавливает фоновую очистку и дожидается завершения текущей
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+func (m *Manager) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(m.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.CleanupInterval)
+		if _, err := m.Cleanup(ctx); err != nil {
+			m.warning(ctx, err.Error())
+		}
+		cancel()
+	}
+}
+
+func (m *Manager) open(value string) ([]byte, bool) {
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+
+	for _, sealer := range m.sealers {
+		if token, err := sealer.Open(sealed, []byte(m.cfg.CookieName)); err == nil {
+			return token, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m *Manager) idle(s *Session, now time.Time) bool {
+	return m.cfg.IdleTimeout > 0 && now.Sub(s.LastSeenAt) > m.cfg.IdleTimeout
+}
+
+func (m *Manager) cookie(value string) http.Cookie {
+	return http.Cookie{
+		Name:     m.cfg.CookieName,
+		Value:    value,
+		Path:     m.cfg.CookiePath,
+		Domain:   m.cfg.CookieDomain,
+		Secure:   m.cfg.CookieSecure,
+		HttpOnly: m.cfg.CookieHTTPOnly,
+		SameSite: m.cfg.SameSite(),
+	}
+}
+
+func (m *Manager) warning(ctx context.Context, msg string) {
+	if m.logger != nil {
+		m.logger.Warning(ctx, msg)
+	}
+}
@@ -0,0 +1,83 @@
+// Package session реализует серверные сессии браузерных клиентов: в cookie лежит только
+// случайный токен, зашифрованный AES-256-GCM (значение нельзя ни прочитать, ни подделать),
+// а сама сессия хранится в Store. Каждая сессия получает CSRF токен, который клиент
+// возвращает в заголовке X-CSRF-Token на изменяющих запросах.
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// HeaderCSRFToken - заголовок, в котором клиент возвращает CSRF токен сессии
+const HeaderCSRFToken = "X-CSRF-Token"
+
+const tokenBytes = 32
+
+var (
+	// ErrNoSession - в запросе нет cookie сессии
+	ErrNoSession = errors.New("no session cookie")
+	// ErrInvalidSession - cookie поврежден, подписан другим ключом или сессия завершена
+	ErrInvalidSession = errors.New("session is invalid or expired")
+)
+
+// Session - сессия пользователя. ID - SHA-256 токена из cookie, поэтому его можно писать
+// в лог, а утечка таблицы не дает войти в чужую сессию.
+type Session struct {
+	ID     string
+	UserID string
+	// Roles - роли владельца на момент загрузки, заполняются хранилищем
+	Roles      []string
+	CSRFToken  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
+
+// ValidCSRF сравнивает token с CSRF токеном сессии за постоянное время
+func (s *Session) ValidCSRF(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.CSRFToken)) == 1
+}
+
+type sessionKey struct{}
+
+// WithSession сохраняет сессию, которой аутентифицирован запрос, в контексте
+func WithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// FromContext возвращает сессию запроса, ok = false для запросов без cookie сессии
+func FromContext(ctx context.Context) (*Session, bool) {
+	s, ok := ctx.Value(sessionKey{}).(*Session)
+	return s, ok && s != nil
+}
+
+func newToken() ([]byte, error) {
+	token := make([]byte, tokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("generate session token: %w", err)
+	}
+
+	return token, nil
+}
+
+func newCSRFToken() (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func sessionID(token []byte) string {
+	sum := sha256.Sum256(token)
+	return hex.EncodeToString(sum[:])
+}